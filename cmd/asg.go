@@ -6,6 +6,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/CharonWare/go-aws/internal/aws"
 	"github.com/spf13/cobra"
@@ -15,8 +16,9 @@ import (
 var asgCmd = &cobra.Command{
 	Use:   "asg",
 	Short: "Describes the scaling values of the ASGs in the current account and region",
-	Long: `Provides Name, MinSize, MaxSize, DesiredCapacity, and the AVG CPU% (over the 
-	last 5 minutes) for each autoscaling group in the current account and region.`,
+	Long: `Provides Name, MinSize, MaxSize, DesiredCapacity, the AVG CPU% (over the 
+	last 5 minutes) and any suspended processes for each autoscaling group in the current
+	account and region.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		region := os.Getenv("AWS_DEFAULT_REGION")
 		if region == "" {
@@ -33,6 +35,10 @@ var asgCmd = &cobra.Command{
 		}
 
 		for _, asg := range groups {
+			suspended := "none"
+			if len(asg.Suspended) > 0 {
+				suspended = strings.Join(asg.Suspended, ", ")
+			}
 			fmt.Printf(`
 
 Name:            %s
@@ -40,6 +46,7 @@ MinSize:         %d
 MaxSize:         %d
 DesiredCapacity: %d
 AVG CPU (5 min): %.1f%%
+Suspended:       %s
 
 `,
 				asg.Name,
@@ -47,6 +54,7 @@ AVG CPU (5 min): %.1f%%
 				asg.MaxSize,
 				asg.DesiredCapacity,
 				asg.AVGCPU,
+				suspended,
 			)
 		}
 		return nil
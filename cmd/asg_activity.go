@@ -0,0 +1,102 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/spf13/cobra"
+)
+
+// asgActivityCmd represents the asg activity command
+var asgActivityCmd = &cobra.Command{
+	Use:   "activity",
+	Short: "Show the scaling activity history of an ASG",
+	Long: `Prompts for an autoscaling group and prints its scaling activities with the time,
+	status, cause and description of each one. Use --since to limit how far back to look and
+	--follow to keep printing activities as they happen.
+	Use with: go-aws asg activity`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		since, _ := cmd.Flags().GetDuration("since")
+		follow, _ := cmd.Flags().GetBool("follow")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		if follow && interval <= 0 {
+			return fmt.Errorf("--interval must be greater than zero")
+		}
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		activities, err := aws.DescribeScalingActivities(region, selectedGroup, time.Now().Add(-since))
+		if err != nil {
+			return err
+		}
+
+		if len(activities) == 0 && !follow {
+			fmt.Printf("No scaling activities found in the last %s\n", since)
+			return nil
+		}
+
+		// Track the last status printed for each activity so that follow mode only prints changes
+		seen := make(map[string]string)
+		for _, activity := range activities {
+			printScalingActivity(activity)
+			seen[activity.ID] = activity.Status
+		}
+
+		if !follow {
+			return nil
+		}
+
+		fmt.Printf("Following scaling activities for %s, press Ctrl+C to stop\n", selectedGroup)
+		for {
+			time.Sleep(interval)
+
+			activities, err := aws.DescribeScalingActivities(region, selectedGroup, time.Now().Add(-since))
+			if err != nil {
+				return err
+			}
+			for _, activity := range activities {
+				if status, ok := seen[activity.ID]; ok && status == activity.Status {
+					continue
+				}
+				printScalingActivity(activity)
+				seen[activity.ID] = activity.Status
+			}
+		}
+	},
+}
+
+func init() {
+	asgCmd.AddCommand(asgActivityCmd)
+
+	asgActivityCmd.Flags().Duration("since", 24*time.Hour, "Only show activities that started within this duration, e.g. 30m, 6h")
+	asgActivityCmd.Flags().BoolP("follow", "f", false, "Keep polling and print new activities as they happen")
+	asgActivityCmd.Flags().Duration("interval", 10*time.Second, "How often to poll for new activities when following")
+}
+
+func printScalingActivity(activity aws.ScalingActivity) {
+	fmt.Printf(`
+Time:        %s
+Status:      %s
+Cause:       %s
+Description: %s
+`,
+		activity.StartTime.Local().Format(time.DateTime),
+		activity.Status,
+		activity.Cause,
+		activity.Description,
+	)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// asgInstancesCmd represents the asg instances command
+var asgInstancesCmd = &cobra.Command{
+	Use:   "instances",
+	Short: "List the instances in an ASG and start an SSM session with one of them",
+	Long: `Prompts for an autoscaling group and then lists its instances along with their
+	lifecycle state, health status, availability zone, launch template version and scale-in
+	protection. Selecting an instance will start an SSM session with it.
+	Use with: go-aws asg instances`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		instances, err := aws.DescribeASGInstances(region, selectedGroup)
+		if err != nil {
+			return err
+		}
+
+		if len(instances) == 0 {
+			fmt.Println("No instances found in this ASG")
+			return nil
+		}
+
+		// Show the instance details in the prompt so the user can pick a healthy instance
+		options := make([]string, len(instances))
+		for i, instance := range instances {
+			options[i] = formatASGInstance(instance)
+		}
+
+		i, _, err := ui.CreatePrompt(options, "Select an instance:")
+		if err != nil {
+			return err
+		}
+
+		return startSSMSession(instances[i].ID)
+	},
+}
+
+func init() {
+	asgCmd.AddCommand(asgInstancesCmd)
+}
+
+// selectASG prompts the user to choose one of the autoscaling groups in the region
+func selectASG(region string) (string, error) {
+	groups, err := aws.ListASGs(region)
+	if err != nil {
+		return "", err
+	}
+
+	if len(groups) == 0 {
+		return "", fmt.Errorf("no ASGs found")
+	}
+
+	i, _, err := ui.CreatePrompt(groups, "Select an ASG:")
+	if err != nil {
+		return "", err
+	}
+
+	return groups[i], nil
+}
+
+func formatASGInstance(instance aws.ASGInstance) string {
+	protection := "unprotected"
+	if instance.ProtectedFromScaleIn {
+		protection = "protected"
+	}
+	return fmt.Sprintf("%-19s  %-18s  %-9s  %-12s  %s  %s",
+		instance.ID,
+		instance.LifecycleState,
+		instance.HealthStatus,
+		instance.AvailabilityZone,
+		instance.LaunchTemplate,
+		protection,
+	)
+}
@@ -0,0 +1,283 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// asgStandbyCmd represents the asg standby command
+var asgStandbyCmd = &cobra.Command{
+	Use:   "standby",
+	Short: "Move ASG instances into or out of Standby",
+	Long: `Prompts for an autoscaling group and the InService instances to move into Standby.
+	Use --exit to instead return Standby instances to service. Use --decrement to lower the
+	desired capacity so that no replacement instances are launched.
+	Use with: go-aws asg standby`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		exit, _ := cmd.Flags().GetBool("exit")
+		decrement, _ := cmd.Flags().GetBool("decrement")
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		state := "InService"
+		if exit {
+			state = "Standby"
+		}
+
+		instanceIDs, err := selectASGInstances(region, selectedGroup, func(instance aws.ASGInstance) bool {
+			return instance.LifecycleState == state
+		})
+		if err != nil || len(instanceIDs) == 0 {
+			return err
+		}
+
+		if exit {
+			if err := aws.ExitStandby(region, selectedGroup, instanceIDs); err != nil {
+				return err
+			}
+			fmt.Printf("Moving %s out of Standby\n", strings.Join(instanceIDs, ", "))
+			return nil
+		}
+
+		if err := aws.EnterStandby(region, selectedGroup, instanceIDs, decrement); err != nil {
+			return err
+		}
+		fmt.Printf("Moving %s into Standby\n", strings.Join(instanceIDs, ", "))
+		return nil
+	},
+}
+
+// asgDetachCmd represents the asg detach command
+var asgDetachCmd = &cobra.Command{
+	Use:   "detach",
+	Short: "Detach instances from an ASG",
+	Long: `Prompts for an autoscaling group and the instances to detach from it after confirmation.
+	Use --decrement to lower the desired capacity so that no replacement instances are launched.
+	Use with: go-aws asg detach`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		decrement, _ := cmd.Flags().GetBool("decrement")
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		instanceIDs, err := selectASGInstances(region, selectedGroup, func(instance aws.ASGInstance) bool {
+			return instance.LifecycleState == "InService" || instance.LifecycleState == "Standby"
+		})
+		if err != nil || len(instanceIDs) == 0 {
+			return err
+		}
+
+		confirmed, err := ui.Confirm(fmt.Sprintf("Detach %s from %s", strings.Join(instanceIDs, ", "), selectedGroup))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted")
+			return nil
+		}
+
+		if err := aws.DetachInstances(region, selectedGroup, instanceIDs, decrement); err != nil {
+			return err
+		}
+		fmt.Printf("Detaching %s\n", strings.Join(instanceIDs, ", "))
+		return nil
+	},
+}
+
+// asgProtectCmd represents the asg protect command
+var asgProtectCmd = &cobra.Command{
+	Use:   "protect",
+	Short: "Set scale-in protection on ASG instances",
+	Long: `Prompts for an autoscaling group and the instances to protect from scale-in.
+	Use --remove to remove the protection instead.
+	Use with: go-aws asg protect`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		// Only offer instances whose protection would actually change
+		instanceIDs, err := selectASGInstances(region, selectedGroup, func(instance aws.ASGInstance) bool {
+			return instance.ProtectedFromScaleIn == remove
+		})
+		if err != nil || len(instanceIDs) == 0 {
+			return err
+		}
+
+		if err := aws.SetInstanceProtection(region, selectedGroup, instanceIDs, !remove); err != nil {
+			return err
+		}
+
+		if remove {
+			fmt.Printf("Removed scale-in protection from %s\n", strings.Join(instanceIDs, ", "))
+		} else {
+			fmt.Printf("Protected %s from scale-in\n", strings.Join(instanceIDs, ", "))
+		}
+		return nil
+	},
+}
+
+// asgLifecycleCmd represents the asg lifecycle command
+var asgLifecycleCmd = &cobra.Command{
+	Use:   "lifecycle",
+	Short: "List and complete pending lifecycle hook actions",
+	Long: `Prompts for an autoscaling group and lists the instances waiting on a lifecycle hook.
+	Selecting one completes its lifecycle action with the result given by --result.
+	Use with: go-aws asg lifecycle`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		result, _ := cmd.Flags().GetString("result")
+		result = strings.ToUpper(result)
+		if result != "CONTINUE" && result != "ABANDON" {
+			return fmt.Errorf("--result must be CONTINUE or ABANDON")
+		}
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		instances, err := aws.DescribeASGInstances(region, selectedGroup)
+		if err != nil {
+			return err
+		}
+
+		hooks, err := aws.DescribeLifecycleHooks(region, selectedGroup)
+		if err != nil {
+			return err
+		}
+
+		// Pair each waiting instance with the hooks that apply to its transition
+		type pendingAction struct {
+			instanceID string
+			hook       string
+		}
+		var actions []pendingAction
+		var options []string
+		for _, instance := range instances {
+			if !strings.HasSuffix(instance.LifecycleState, ":Wait") {
+				continue
+			}
+			transition := "autoscaling:EC2_INSTANCE_LAUNCHING"
+			if strings.Contains(instance.LifecycleState, "Terminating") {
+				transition = "autoscaling:EC2_INSTANCE_TERMINATING"
+			}
+			for _, hook := range hooks {
+				if hook.Transition != transition {
+					continue
+				}
+				actions = append(actions, pendingAction{instanceID: instance.ID, hook: hook.Name})
+				options = append(options, fmt.Sprintf("%-19s  %-17s  %s (heartbeat %ds, default %s)",
+					instance.ID,
+					instance.LifecycleState,
+					hook.Name,
+					hook.HeartbeatTimeout,
+					hook.DefaultResult,
+				))
+			}
+		}
+
+		if len(actions) == 0 {
+			fmt.Println("No pending lifecycle actions found")
+			return nil
+		}
+
+		i, _, err := ui.CreatePrompt(options, fmt.Sprintf("Select a lifecycle action to %s:", result))
+		if err != nil {
+			return err
+		}
+
+		selected := actions[i]
+		if err := aws.CompleteLifecycleAction(region, selectedGroup, selected.hook, selected.instanceID, result); err != nil {
+			return err
+		}
+		fmt.Printf("Completed %s for %s with %s\n", selected.hook, selected.instanceID, result)
+		return nil
+	},
+}
+
+func init() {
+	asgCmd.AddCommand(asgStandbyCmd)
+	asgCmd.AddCommand(asgDetachCmd)
+	asgCmd.AddCommand(asgProtectCmd)
+	asgCmd.AddCommand(asgLifecycleCmd)
+
+	asgStandbyCmd.Flags().Bool("exit", false, "Move Standby instances back into service")
+	asgStandbyCmd.Flags().Bool("decrement", false, "Decrement the desired capacity when entering Standby")
+	asgDetachCmd.Flags().Bool("decrement", false, "Decrement the desired capacity when detaching")
+	asgProtectCmd.Flags().Bool("remove", false, "Remove scale-in protection instead of setting it")
+	asgLifecycleCmd.Flags().String("result", "CONTINUE", "Lifecycle action result, CONTINUE or ABANDON")
+}
+
+// selectASGInstances prompts the user to choose any number of the group's instances that match the filter
+func selectASGInstances(region, group string, filter func(aws.ASGInstance) bool) ([]string, error) {
+	instances, err := aws.DescribeASGInstances(region, group)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []aws.ASGInstance
+	var options []string
+	for _, instance := range instances {
+		if filter(instance) {
+			candidates = append(candidates, instance)
+			options = append(options, formatASGInstance(instance))
+		}
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("No eligible instances found in this ASG")
+		return nil, nil
+	}
+
+	indexes, err := ui.CreateMultiSelectPrompt(options, "Select instances:")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indexes) == 0 {
+		fmt.Println("No instances selected")
+		return nil, nil
+	}
+
+	instanceIDs := make([]string, len(indexes))
+	for i, index := range indexes {
+		instanceIDs[i] = candidates[index].ID
+	}
+	return instanceIDs, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// asgSuspendCmd represents the asg suspend command
+var asgSuspendCmd = &cobra.Command{
+	Use:   "suspend",
+	Short: "Suspend scaling processes on an ASG",
+	Long: `Prompts for an autoscaling group and the scaling processes to suspend, e.g. Launch,
+	Terminate, ReplaceUnhealthy or AZRebalance, and suspends them after confirmation.
+	Use with: go-aws asg suspend`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeASGProcesses(cmd, true)
+	},
+}
+
+// asgResumeCmd represents the asg resume command
+var asgResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume suspended scaling processes on an ASG",
+	Long: `Prompts for an autoscaling group and the suspended processes to resume, and resumes
+	them after confirmation.
+	Use with: go-aws asg resume`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeASGProcesses(cmd, false)
+	},
+}
+
+func init() {
+	asgCmd.AddCommand(asgSuspendCmd)
+	asgCmd.AddCommand(asgResumeCmd)
+
+	asgSuspendCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	asgResumeCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+}
+
+func changeASGProcesses(cmd *cobra.Command, suspend bool) error {
+	region := os.Getenv("AWS_DEFAULT_REGION")
+	if region == "" {
+		region = "eu-west-1" // Default region if the environment variable is not set
+	}
+
+	selectedGroup, err := selectASG(region)
+	if err != nil {
+		return err
+	}
+
+	suspended, err := aws.GetSuspendedProcesses(region, selectedGroup)
+	if err != nil {
+		return err
+	}
+
+	if len(suspended) > 0 {
+		fmt.Printf("Currently suspended: %s\n", strings.Join(suspended, ", "))
+	} else {
+		fmt.Println("Currently suspended: none")
+	}
+
+	// Only offer processes that can actually change state
+	var options []string
+	for _, process := range aws.ScalingProcesses {
+		isSuspended := false
+		for _, s := range suspended {
+			if s == process {
+				isSuspended = true
+				break
+			}
+		}
+		if isSuspended != suspend {
+			options = append(options, process)
+		}
+	}
+
+	action, verb, done := "suspend", "Suspend", "suspended"
+	if !suspend {
+		action, verb, done = "resume", "Resume", "resumed"
+	}
+
+	if len(options) == 0 {
+		fmt.Printf("There are no processes to %s\n", action)
+		return nil
+	}
+
+	indexes, err := ui.CreateMultiSelectPrompt(options, fmt.Sprintf("Select processes to %s:", action))
+	if err != nil {
+		return err
+	}
+
+	if len(indexes) == 0 {
+		fmt.Println("No processes selected")
+		return nil
+	}
+
+	processes := make([]string, len(indexes))
+	for i, index := range indexes {
+		processes[i] = options[index]
+	}
+
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	if !skipConfirm {
+		confirmed, err := ui.Confirm(fmt.Sprintf("%s %s on %s", verb, strings.Join(processes, ", "), selectedGroup))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	if suspend {
+		err = aws.SuspendProcesses(region, selectedGroup, processes)
+	} else {
+		err = aws.ResumeProcesses(region, selectedGroup, processes)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: %s %s\n", selectedGroup, done, strings.Join(processes, ", "))
+	return nil
+}
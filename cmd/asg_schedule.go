@@ -0,0 +1,261 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// asgScheduleCmd represents the asg schedule command
+var asgScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage the scheduled scaling actions of an ASG",
+	Long: `List, add and delete the scheduled scaling actions of an autoscaling group.
+	Use with: go-aws asg schedule list|add|delete`,
+}
+
+// asgScheduleListCmd represents the asg schedule list command
+var asgScheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the scheduled actions of an ASG along with when they will next run",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		actions, err := aws.DescribeScheduledActions(region, selectedGroup)
+		if err != nil {
+			return err
+		}
+
+		if len(actions) == 0 {
+			fmt.Println("No scheduled actions found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tRECURRENCE\tTIME ZONE\tMIN\tMAX\tDESIRED\tNEXT RUN")
+		for _, action := range actions {
+			recurrence := action.Recurrence
+			if recurrence == "" {
+				recurrence = "once"
+			}
+			timeZone := action.TimeZone
+			if timeZone == "" {
+				timeZone = "UTC"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				action.Name,
+				recurrence,
+				timeZone,
+				formatOptionalSize(action.MinSize),
+				formatOptionalSize(action.MaxSize),
+				formatOptionalSize(action.DesiredCapacity),
+				formatNextRun(action.NextRun),
+			)
+		}
+		return w.Flush()
+	},
+}
+
+// asgScheduleAddCmd represents the asg schedule add command
+var asgScheduleAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add or update a scheduled action on an ASG",
+	Long: `Creates or updates a scheduled action on an autoscaling group. Recurring actions take a
+	standard five field cron expression which is evaluated in --time-zone (UTC by default).
+	Use with: go-aws asg schedule add --name nightly-scale-down --recurrence "0 20 * * MON-FRI"
+	--time-zone Europe/London --min 0 --max 0 --desired 0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		name, _ := cmd.Flags().GetString("name")
+		recurrence, _ := cmd.Flags().GetString("recurrence")
+		timeZone, _ := cmd.Flags().GetString("time-zone")
+		startTime, _ := cmd.Flags().GetString("start-time")
+		endTime, _ := cmd.Flags().GetString("end-time")
+
+		if name == "" {
+			return fmt.Errorf("--name is required")
+		}
+		if recurrence == "" && startTime == "" {
+			return fmt.Errorf("either --recurrence or --start-time is required")
+		}
+
+		action := aws.ScheduledAction{
+			Name:       name,
+			Recurrence: recurrence,
+			TimeZone:   timeZone,
+		}
+
+		// Validate the cron expression and time zone before sending anything to AWS
+		location := time.UTC
+		if recurrence != "" || timeZone != "" {
+			var err error
+			if recurrence != "" {
+				_, location, err = aws.ParseRecurrence(recurrence, timeZone)
+			} else {
+				location, err = time.LoadLocation(timeZone)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if startTime != "" {
+			t, err := parseScheduleTime(startTime, location)
+			if err != nil {
+				return fmt.Errorf("invalid --start-time: %w", err)
+			}
+			action.StartTime = &t
+		}
+		if endTime != "" {
+			t, err := parseScheduleTime(endTime, location)
+			if err != nil {
+				return fmt.Errorf("invalid --end-time: %w", err)
+			}
+			action.EndTime = &t
+		}
+
+		// Sizes are only sent when the flag is given so that an action can change just one of them
+		if cmd.Flags().Changed("min") {
+			minSize, _ := cmd.Flags().GetInt32("min")
+			action.MinSize = &minSize
+		}
+		if cmd.Flags().Changed("max") {
+			maxSize, _ := cmd.Flags().GetInt32("max")
+			action.MaxSize = &maxSize
+		}
+		if cmd.Flags().Changed("desired") {
+			desired, _ := cmd.Flags().GetInt32("desired")
+			action.DesiredCapacity = &desired
+		}
+		if action.MinSize == nil && action.MaxSize == nil && action.DesiredCapacity == nil {
+			return fmt.Errorf("at least one of --min, --max or --desired is required")
+		}
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		if err := aws.PutScheduledAction(region, selectedGroup, action); err != nil {
+			return err
+		}
+
+		nextRun, _ := aws.NextScheduledRun(action, time.Now())
+		fmt.Printf("Scheduled action %s saved on %s, next run: %s\n", name, selectedGroup, formatNextRun(nextRun))
+		return nil
+	},
+}
+
+// asgScheduleDeleteCmd represents the asg schedule delete command
+var asgScheduleDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a scheduled action from an ASG",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		selectedGroup, err := selectASG(region)
+		if err != nil {
+			return err
+		}
+
+		actions, err := aws.DescribeScheduledActions(region, selectedGroup)
+		if err != nil {
+			return err
+		}
+
+		if len(actions) == 0 {
+			fmt.Println("No scheduled actions found")
+			return nil
+		}
+
+		options := make([]string, len(actions))
+		for i, action := range actions {
+			options[i] = fmt.Sprintf("%s (next run: %s)", action.Name, formatNextRun(action.NextRun))
+		}
+
+		i, _, err := ui.CreatePrompt(options, "Select a scheduled action to delete:")
+		if err != nil {
+			return err
+		}
+
+		selectedAction := actions[i]
+		confirmed, err := ui.Confirm(fmt.Sprintf("Delete %s from %s", selectedAction.Name, selectedGroup))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted")
+			return nil
+		}
+
+		if err := aws.DeleteScheduledAction(region, selectedGroup, selectedAction.Name); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted scheduled action %s\n", selectedAction.Name)
+		return nil
+	},
+}
+
+func init() {
+	asgCmd.AddCommand(asgScheduleCmd)
+	asgScheduleCmd.AddCommand(asgScheduleListCmd)
+	asgScheduleCmd.AddCommand(asgScheduleAddCmd)
+	asgScheduleCmd.AddCommand(asgScheduleDeleteCmd)
+
+	asgScheduleAddCmd.Flags().String("name", "", "Name of the scheduled action")
+	asgScheduleAddCmd.Flags().String("recurrence", "", "Cron expression for recurring actions, e.g. \"0 20 * * *\"")
+	asgScheduleAddCmd.Flags().String("time-zone", "", "IANA time zone for the recurrence and times, e.g. Europe/London (default UTC)")
+	asgScheduleAddCmd.Flags().String("start-time", "", "When the action first runs, as RFC3339 or \"2006-01-02 15:04\"")
+	asgScheduleAddCmd.Flags().String("end-time", "", "When a recurring action stops, as RFC3339 or \"2006-01-02 15:04\"")
+	asgScheduleAddCmd.Flags().Int32("min", 0, "Minimum size to set")
+	asgScheduleAddCmd.Flags().Int32("max", 0, "Maximum size to set")
+	asgScheduleAddCmd.Flags().Int32("desired", 0, "Desired capacity to set")
+}
+
+// parseScheduleTime accepts RFC3339, or a plain date and time in the schedule's time zone
+func parseScheduleTime(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02 15:04", value, location)
+}
+
+func formatOptionalSize(size *int32) string {
+	if size == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", *size)
+}
+
+func formatNextRun(next time.Time) string {
+	if next.IsZero() {
+		return "never"
+	}
+	return fmt.Sprintf("%s (in %s)",
+		next.Format("2006-01-02 15:04 MST"),
+		time.Until(next).Round(time.Minute),
+	)
+}
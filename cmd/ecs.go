@@ -8,6 +8,9 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/CharonWare/go-aws/internal/aws"
 	"github.com/CharonWare/go-aws/internal/ui"
@@ -27,20 +30,11 @@ var ecsCmd = &cobra.Command{
 			region = "eu-west-1" // Default region if the environment variable is not set
 		}
 
-		// Search for available ECS clusters in the chosen region
-		clusters, err := aws.ListClusters(region)
+		selectedCluster, err := selectCluster(region)
 		if err != nil {
 			return err
 		}
 
-		// Prompt the user to select a cluster
-		i, _, err := ui.CreatePrompt(clusters, "Select a cluster:")
-		if err != nil {
-			return err
-		}
-
-		selectedCluster := clusters[i]
-
 		// Check if the describe-cluster flag is set and proceed based on that
 		describeClusterBool, _ := cmd.Flags().GetBool("describe-cluster")
 		if describeClusterBool {
@@ -48,20 +42,11 @@ var ecsCmd = &cobra.Command{
 			os.Exit(0)
 		}
 
-		// Pass the selected cluster to a list services call to see all services in that cluster
-		services, err := aws.ListServices(region, selectedCluster)
-		if err != nil {
-			return err
-		}
-
-		// Prompt the user to select a service
-		ii, _, err := ui.CreatePrompt(services, "Select a service:")
+		selectedService, err := selectService(region, selectedCluster)
 		if err != nil {
 			return err
 		}
 
-		selectedService := services[ii]
-
 		// Check if the describe-service flag is set and proceed based on that
 		describeServiceBool, _ := cmd.Flags().GetBool("describe-service")
 		if describeServiceBool {
@@ -70,17 +55,33 @@ var ecsCmd = &cobra.Command{
 		}
 
 		// Pass the selected cluster and service to a list tasks call to see all tasks in that service
-		tasks, err := aws.ListTasks(region, selectedCluster, selectedService)
+		task, err := selectTask(region, selectedCluster, selectedService)
 		if err != nil {
 			return err
 		}
 
-		iii, _, err := ui.CreatePrompt(tasks, "Select a task:")
-		if err != nil {
-			return err
+		// Check if the describe-task flag is set and proceed based on that
+		describeTaskBool, _ := cmd.Flags().GetBool("describe-task")
+		if describeTaskBool {
+			describeTask(task)
+			os.Exit(0)
+		}
+
+		// Check if the host flag is set and open an SSM session on the task's EC2 instance instead
+		hostBool, _ := cmd.Flags().GetBool("host")
+		if hostBool {
+			if task.ContainerInstance == "" {
+				return fmt.Errorf("task %s is not running on a container instance, Fargate tasks have no host to connect to", aws.TaskID(task.Arn))
+			}
+			instanceID, err := aws.GetContainerInstanceEC2ID(region, selectedCluster, task.ContainerInstance)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Task %s is running on %s\n", aws.TaskID(task.Arn), instanceID)
+			return startSSMSession(instanceID)
 		}
 
-		selectedTask := tasks[iii]
+		selectedTask := task.Arn
 
 		// Tasks can have multiple containers so we need to describe them to find the container names
 		containers, err := aws.DescribeTasks(region, selectedCluster, selectedTask)
@@ -102,7 +103,8 @@ var ecsCmd = &cobra.Command{
 		// if task-definition flag is set, stop here and describe the task-definition for this task
 		taskDefinitionBool, _ := cmd.Flags().GetBool("task-definition")
 		if taskDefinitionBool {
-			output, err := aws.DescribeTaskDefinition(region, containers[0].TaskDefinitionArn)
+			format, _ := cmd.Flags().GetString("format")
+			output, err := aws.ExportTaskDefinition(region, containers[0].TaskDefinitionArn, format)
 			if err != nil {
 				return err
 			}
@@ -111,18 +113,36 @@ var ecsCmd = &cobra.Command{
 		}
 
 		// // If only one container exists, skip the prompt
-		if len(containerNames) == 1 {
-			return execToContainer(region, selectedCluster, selectedTask, containers[0].Name)
+		selectedContainer := containers[0]
+		if len(containerNames) > 1 {
+			// Otherwise, select a container to exec to
+			iiii, _, err := ui.CreatePrompt(containerNames, "Select a container:")
+			if err != nil {
+				return err
+			}
+			selectedContainer = containers[iiii]
 		}
 
-		// Otherwise, select a container to exec to
-		iiii, _, err := ui.CreatePrompt(containerNames, "Select a container:")
-		if err != nil {
-			return err
+		// Check ECS Exec can work before handing over to the AWS CLI, whose errors are hard to act on
+		skipPreflight, _ := cmd.Flags().GetBool("skip-preflight")
+		if !skipPreflight {
+			checks, err := runExecPreflight(region, selectedCluster, task, selectedContainer.Name)
+			if err != nil {
+				return err
+			}
+			// Only report the checks when something needs attention, warnings do not stop the session
+			needsAttention := false
+			for _, check := range checks {
+				if check.status != "PASS" {
+					needsAttention = true
+				}
+			}
+			if needsAttention && !printExecChecks(checks) {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("ECS Exec preflight failed, use --skip-preflight to try anyway")
+			}
 		}
 
-		selectedContainer := containers[iiii]
-
 		return execToContainer(region, selectedCluster, selectedTask, selectedContainer.Name)
 
 	},
@@ -133,7 +153,11 @@ func init() {
 
 	ecsCmd.Flags().BoolP("describe-cluster", "c", false, "Describe the selected cluster")
 	ecsCmd.Flags().BoolP("describe-service", "s", false, "Describe the selected service")
+	ecsCmd.Flags().BoolP("describe-task", "d", false, "Describe the selected task")
 	ecsCmd.Flags().BoolP("task-definition", "t", false, "Show the task definition for the selected task")
+	ecsCmd.Flags().String("format", "json", "Task definition output format: json (also called register-input) or yaml, both can be registered again with ecs taskdef import")
+	ecsCmd.Flags().Bool("host", false, "Start an SSM session on the EC2 instance hosting the selected task")
+	ecsCmd.Flags().Bool("skip-preflight", false, "Skip the ECS Exec preflight checks before connecting")
 
 	// Here you will define your flags and configuration settings.
 
@@ -146,6 +170,216 @@ func init() {
 	// execCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
+// selectCluster prompts the user to choose one of the ECS clusters in the region.
+// Clusters are shown by name but the full ARN is returned.
+func selectCluster(region string) (string, error) {
+	// Search for available ECS clusters in the chosen region
+	clusters, err := aws.ListClusters(region)
+	if err != nil {
+		return "", err
+	}
+
+	if len(clusters) == 0 {
+		return "", fmt.Errorf("no ECS clusters found")
+	}
+
+	summaries, err := aws.DescribeClusterSummaries(region, clusters)
+	if err != nil {
+		return "", err
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	options := make([]string, len(summaries))
+	arns := make([]string, len(summaries))
+	for i, summary := range summaries {
+		options[i] = fmt.Sprintf("%-40s %d services, %d running tasks", summary.Name, summary.Services, summary.RunningTasks)
+		arns[i] = summary.Arn
+	}
+
+	// Prompt the user to select a cluster
+	i, _, err := ui.CreateSearchPrompt(options, arns, "Select a cluster:")
+	if err != nil {
+		return "", err
+	}
+
+	return arns[i], nil
+}
+
+// selectService prompts the user to choose one of the services in the cluster.
+// Services are shown by name but the full ARN is returned.
+func selectService(region, cluster string) (string, error) {
+	// Pass the selected cluster to a list services call to see all services in that cluster
+	services, err := aws.ListServices(region, cluster)
+	if err != nil {
+		return "", err
+	}
+
+	if len(services) == 0 {
+		return "", fmt.Errorf("no services found in the selected cluster")
+	}
+
+	statuses, err := aws.GetServiceStatuses(region, cluster, services)
+	if err != nil {
+		return "", err
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	options := make([]string, len(statuses))
+	arns := make([]string, len(statuses))
+	for i, status := range statuses {
+		options[i] = fmt.Sprintf("%-40s running %d/%d", status.Name, status.Running, status.Desired)
+		arns[i] = status.Arn
+	}
+
+	// Prompt the user to select a service
+	i, _, err := ui.CreateSearchPrompt(options, arns, "Select a service:")
+	if err != nil {
+		return "", err
+	}
+
+	return arns[i], nil
+}
+
+// selectTask prompts the user to choose one of the service's running tasks, showing each task's status in the prompt
+func selectTask(region, cluster, service string) (aws.TaskStatus, error) {
+	// Pass the selected cluster and service to a list tasks call to see all tasks in that service
+	tasks, err := aws.ListTasks(region, cluster, service)
+	if err != nil {
+		return aws.TaskStatus{}, err
+	}
+
+	if len(tasks) == 0 {
+		return aws.TaskStatus{}, fmt.Errorf("no running tasks found for the selected service")
+	}
+
+	statuses, options, err := describeTaskOptions(region, cluster, tasks)
+	if err != nil {
+		return aws.TaskStatus{}, err
+	}
+
+	i, _, err := ui.CreatePrompt(options, "Select a task:")
+	if err != nil {
+		return aws.TaskStatus{}, err
+	}
+
+	return statuses[i], nil
+}
+
+// describeTaskOptions describes the tasks and returns them with a matching prompt entry for each one
+func describeTaskOptions(region, cluster string, tasks []string) ([]aws.TaskStatus, []string, error) {
+	statuses, err := aws.DescribeTaskStatuses(region, cluster, tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Keep the prompt order stable between runs
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].StartedAt.Before(statuses[j].StartedAt)
+	})
+
+	options := make([]string, len(statuses))
+	for i, status := range statuses {
+		options[i] = fmt.Sprintf("%s  %-8s %-9s %-12s %-15s %-24s %-8s %s",
+			status.ID[:min(8, len(status.ID))],
+			status.LastStatus,
+			status.HealthStatus,
+			status.AvailabilityZone,
+			status.PrivateIP,
+			taskDefinitionRevision(status.TaskDefinitionArn),
+			formatAge(status.StartedAt),
+			status.LaunchType,
+		)
+	}
+	return statuses, options, nil
+}
+
+func describeTask(task aws.TaskStatus) {
+	fmt.Printf(`
+Task:            %s
+Last Status:     %s
+Desired Status:  %s
+Health:          %s
+Task Definition: %s
+Launch Type:     %s
+CPU / Memory:    %s / %s
+AZ:              %s
+Private IP:      %s
+Created:         %s
+Started:         %s
+`,
+		task.ID,
+		task.LastStatus,
+		task.DesiredStatus,
+		task.HealthStatus,
+		taskDefinitionRevision(task.TaskDefinitionArn),
+		task.LaunchType,
+		task.Cpu,
+		task.Memory,
+		task.AvailabilityZone,
+		task.PrivateIP,
+		formatTimeAndAge(task.CreatedAt),
+		formatTimeAndAge(task.StartedAt),
+	)
+
+	fmt.Println("\nContainers:")
+	for _, container := range task.Containers {
+		fmt.Printf("  %-20s %-8s %-9s %s\n", container.Name, container.LastStatus, container.HealthStatus, container.Image)
+		if container.RuntimeID != "" {
+			fmt.Printf("  %-20s runtime ID: %s\n", "", container.RuntimeID)
+		}
+		if container.Reason != "" {
+			fmt.Printf("  %-20s reason: %s\n", "", container.Reason)
+		}
+	}
+
+	if len(task.Attachments) > 0 {
+		fmt.Println("\nAttachments:")
+		for _, attachment := range task.Attachments {
+			fmt.Printf("  %s  %s  %s\n", attachment.Type, attachment.Status, attachment.ID)
+			keys := make([]string, 0, len(attachment.Details))
+			for key := range attachment.Details {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fmt.Printf("    %s: %s\n", key, attachment.Details[key])
+			}
+		}
+	}
+}
+
+// taskDefinitionRevision shortens a task definition ARN to family:revision
+func taskDefinitionRevision(taskDefinitionArn string) string {
+	return taskDefinitionArn[strings.LastIndex(taskDefinitionArn, "/")+1:]
+}
+
+// formatAge renders how long ago t was, to the nearest minute once it is over an hour
+// formatTimeAndAge shows a time along with how long ago it was, or - if it has not happened yet,
+// e.g. the start time of a task that is still provisioning
+func formatTimeAndAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Local().Format(time.DateTime), formatAge(t))
+}
+
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	age := time.Since(t)
+	if age > time.Hour {
+		return age.Round(time.Minute).String()
+	}
+	return age.Round(time.Second).String()
+}
+
 func execToContainer(region, cluster, taskArn, container string) error {
 	cmd := exec.Command("aws", "ecs", "execute-command",
 		"--cluster", cluster,
@@ -245,3 +479,14 @@ AVG CPU (5 min): %.1f%%
 	}
 	return nil
 }
+
+// printNewServiceEvents prints the events created after since that have not already been printed
+func printNewServiceEvents(events []aws.ServiceEvent, since time.Time, seen map[string]bool) {
+	for _, event := range events {
+		if seen[event.ID] || event.CreatedAt.Before(since) {
+			continue
+		}
+		seen[event.ID] = true
+		fmt.Printf("  %s  %s\n", event.CreatedAt.Local().Format(time.DateTime), event.Message)
+	}
+}
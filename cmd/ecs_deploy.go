@@ -0,0 +1,126 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ecsDeployCmd represents the ecs deploy command
+var ecsDeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy a new container image to an ECS service",
+	Long: `Prompts for a cluster and service, registers a new revision of the service's current task
+	definition with the given container images, updates the service to use it and then watches
+	the rollout until it completes or fails.
+	Use with: go-aws ecs deploy --image container=repo:tag [--image other=repo:tag]`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		imageFlags, _ := cmd.Flags().GetStringArray("image")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+
+		if len(imageFlags) == 0 {
+			return fmt.Errorf("at least one --image container=repo:tag is required")
+		}
+
+		images := make(map[string]string)
+		for _, flag := range imageFlags {
+			container, image, ok := strings.Cut(flag, "=")
+			if !ok || container == "" || image == "" {
+				return fmt.Errorf("invalid --image %q, expected container=repo:tag", flag)
+			}
+			images[container] = image
+		}
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		taskDefinition, tags, err := aws.GetTaskDefinition(region, status.TaskDefinition)
+		if err != nil {
+			return err
+		}
+
+		input := aws.NewRegisterInput(taskDefinition, tags)
+		previous, err := aws.SetContainerImages(input, images)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Current task definition: %s\n", status.TaskDefinition)
+		// Sort the containers so the summary reads the same on every run
+		containers := make([]string, 0, len(images))
+		for container := range images {
+			containers = append(containers, container)
+		}
+		sort.Strings(containers)
+		for _, container := range containers {
+			fmt.Printf("  %s: %s -> %s\n", container, previous[container], images[container])
+		}
+
+		if !skipConfirm {
+			confirmed, err := ui.Confirm(fmt.Sprintf("Deploy to %s", status.Name))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		taskDefinitionArn, err := aws.RegisterTaskDefinition(region, input)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Registered %s\n", taskDefinitionArn)
+
+		startTime := time.Now()
+		deploymentID, err := aws.UpdateServiceTaskDefinition(region, selectedCluster, selectedService, taskDefinitionArn)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Started deployment %s\n", deploymentID)
+
+		if noWait {
+			return nil
+		}
+
+		return watchRollout(region, selectedCluster, selectedService, deploymentID, startTime, timeout)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsDeployCmd)
+
+	ecsDeployCmd.Flags().StringArray("image", nil, "Container image to deploy as container=repo:tag, can be repeated")
+	ecsDeployCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	ecsDeployCmd.Flags().Bool("no-wait", false, "Return as soon as the deployment has started")
+	ecsDeployCmd.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the rollout to finish")
+}
@@ -0,0 +1,162 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ecsEnableExecCmd represents the ecs enable-exec command
+var ecsEnableExecCmd = &cobra.Command{
+	Use:   "enable-exec",
+	Short: "Turn ECS Exec on or off for an ECS service",
+	Long: `Prompts for a cluster and service and sets its enableExecuteCommand flag, or clears it with
+	--disable. Only tasks started after the change get the exec agent, so use --force-new-deployment
+	to replace the running tasks straight away. Running tasks that still lack the agent are reported.
+	Use with: go-aws ecs enable-exec`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		disable, _ := cmd.Flags().GetBool("disable")
+		forceNewDeployment, _ := cmd.Flags().GetBool("force-new-deployment")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		enable := !disable
+		action := "Enable"
+		if disable {
+			action = "Disable"
+		}
+
+		if status.ExecuteCommand == enable && !forceNewDeployment {
+			fmt.Printf("ECS Exec is already %s on %s\n", enabledOrDisabled(enable), status.Name)
+			return reportTasksWithoutExecAgent(region, selectedCluster, selectedService, enable)
+		}
+
+		if !skipConfirm {
+			label := fmt.Sprintf("%s ECS Exec on %s", action, status.Name)
+			if forceNewDeployment {
+				label += " and force a new deployment"
+			}
+			confirmed, err := ui.Confirm(label)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		startTime := time.Now()
+		deploymentID, err := aws.UpdateServiceExecuteCommand(region, selectedCluster, selectedService, enable, forceNewDeployment)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("ECS Exec %s on %s\n", enabledOrDisabled(enable), status.Name)
+
+		if !forceNewDeployment {
+			return reportTasksWithoutExecAgent(region, selectedCluster, selectedService, enable)
+		}
+
+		fmt.Printf("Started deployment %s\n", deploymentID)
+		if noWait {
+			return nil
+		}
+
+		if err := watchRollout(region, selectedCluster, selectedService, deploymentID, startTime, timeout); err != nil {
+			return err
+		}
+		return reportTasksWithoutExecAgent(region, selectedCluster, selectedService, enable)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsEnableExecCmd)
+
+	ecsEnableExecCmd.Flags().Bool("disable", false, "Turn ECS Exec off instead of on")
+	ecsEnableExecCmd.Flags().Bool("force-new-deployment", false, "Force a new deployment so that running tasks are replaced")
+	ecsEnableExecCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	ecsEnableExecCmd.Flags().Bool("no-wait", false, "Return as soon as the deployment has started")
+	ecsEnableExecCmd.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the rollout to finish")
+}
+
+// reportTasksWithoutExecAgent lists the service's running tasks whose exec setting does not match the
+// service yet. When ECS Exec is being enabled this also includes tasks whose agent is not running.
+func reportTasksWithoutExecAgent(region, cluster, service string, enabled bool) error {
+	taskArns, err := aws.ListTasks(region, cluster, service)
+	if err != nil {
+		return err
+	}
+	if len(taskArns) == 0 {
+		return nil
+	}
+
+	tasks, err := aws.DescribeTaskStatuses(region, cluster, taskArns)
+	if err != nil {
+		return err
+	}
+
+	var outdated []string
+	outdatedTasks := 0
+	for _, task := range tasks {
+		before := len(outdated)
+		if task.ExecuteCommand != enabled {
+			outdated = append(outdated, fmt.Sprintf("%s  execute command %s", aws.TaskID(task.Arn), enabledOrDisabled(task.ExecuteCommand)))
+		} else if enabled {
+			for _, container := range task.Containers {
+				if container.ExecAgent != "RUNNING" {
+					outdated = append(outdated, fmt.Sprintf("%s  exec agent in %s is %s", aws.TaskID(task.Arn), container.Name, valueOrUnknown(container.ExecAgent)))
+				}
+			}
+		}
+		if len(outdated) > before {
+			outdatedTasks++
+		}
+	}
+
+	if outdatedTasks == 0 {
+		fmt.Printf("All %d running tasks match the service\n", len(tasks))
+		return nil
+	}
+
+	fmt.Printf("%d of %d running tasks do not match the service yet:\n", outdatedTasks, len(tasks))
+	for _, line := range outdated {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Println("Replace them with --force-new-deployment or go-aws ecs restart-task")
+	return nil
+}
+
+func enabledOrDisabled(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/spf13/cobra"
+)
+
+// ecsEventsCmd represents the ecs events command
+var ecsEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show the event messages of an ECS service",
+	Long: `Prompts for a cluster and service and prints the service's events with their timestamps.
+	Use --all to show the events of every service in the cluster and --follow to keep polling
+	and print new events as they arrive.
+	Use with: go-aws ecs events`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		all, _ := cmd.Flags().GetBool("all")
+		follow, _ := cmd.Flags().GetBool("follow")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		sinceDuration, _ := cmd.Flags().GetDuration("since")
+
+		if follow && interval <= 0 {
+			return fmt.Errorf("--interval must be greater than zero")
+		}
+
+		var since time.Time
+		if sinceDuration > 0 {
+			since = time.Now().Add(-sinceDuration)
+		}
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		var services []string
+		if all {
+			services, err = aws.ListServices(region, selectedCluster)
+			if err != nil {
+				return err
+			}
+			if len(services) == 0 {
+				return fmt.Errorf("no services found in the selected cluster")
+			}
+		} else {
+			selectedService, err := selectService(region, selectedCluster)
+			if err != nil {
+				return err
+			}
+			services = []string{selectedService}
+		}
+
+		seen := make(map[string]bool)
+		for {
+			statuses, err := aws.GetServiceStatuses(region, selectedCluster, services)
+			if err != nil {
+				return err
+			}
+
+			printNewClusterEvents(statuses, since, seen, all)
+
+			if !follow {
+				return nil
+			}
+			time.Sleep(interval)
+		}
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsEventsCmd)
+
+	ecsEventsCmd.Flags().BoolP("all", "a", false, "Show events for every service in the cluster")
+	ecsEventsCmd.Flags().BoolP("follow", "f", false, "Keep polling and print new events as they arrive")
+	ecsEventsCmd.Flags().Duration("interval", 10*time.Second, "How often to poll for new events when following")
+	ecsEventsCmd.Flags().Duration("since", 0, "Only show events from within this duration, e.g. 30m (default all)")
+}
+
+// printNewClusterEvents interleaves the unseen events of several services in time order,
+// prefixing each with its service name when showPrefix is set
+func printNewClusterEvents(statuses []aws.ServiceStatus, since time.Time, seen map[string]bool, showPrefix bool) {
+	type serviceEvent struct {
+		service string
+		event   aws.ServiceEvent
+	}
+
+	var events []serviceEvent
+	for _, status := range statuses {
+		for _, event := range status.Events {
+			if seen[event.ID] || event.CreatedAt.Before(since) {
+				continue
+			}
+			seen[event.ID] = true
+			events = append(events, serviceEvent{service: status.Name, event: event})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].event.CreatedAt.Before(events[j].event.CreatedAt)
+	})
+
+	for _, e := range events {
+		if showPrefix {
+			fmt.Printf("%s  [%s]  %s\n", e.event.CreatedAt.Local().Format(time.DateTime), e.service, e.event.Message)
+		} else {
+			fmt.Printf("%s  %s\n", e.event.CreatedAt.Local().Format(time.DateTime), e.event.Message)
+		}
+	}
+}
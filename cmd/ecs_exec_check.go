@@ -0,0 +1,312 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ssmMessagesActions are the permissions the task role needs for the exec agent to open a session
+var ssmMessagesActions = []string{
+	"ssmmessages:CreateControlChannel",
+	"ssmmessages:CreateDataChannel",
+	"ssmmessages:OpenControlChannel",
+	"ssmmessages:OpenDataChannel",
+}
+
+// execLogActions are the permissions the task role needs for the exec agent to log sessions to CloudWatch
+var execLogActions = []string{
+	"logs:CreateLogStream",
+	"logs:DescribeLogGroups",
+	"logs:DescribeLogStreams",
+	"logs:PutLogEvents",
+}
+
+// ecsExecCheckCmd represents the ecs exec-check command
+var ecsExecCheckCmd = &cobra.Command{
+	Use:   "exec-check",
+	Short: "Check that ECS Exec will work for a task before trying to connect",
+	Long: `Prompts for a cluster, service, task and container and checks everything ECS Exec needs:
+	enableExecuteCommand on the task, the exec agent running in the container, the task role's SSM
+	permissions, the session-manager-plugin being installed and the cluster's KMS and logging
+	configuration. Each check is reported as PASS, WARN or FAIL with how to fix it.
+	Use with: go-aws ecs exec-check`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		task, err := selectTask(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		if len(task.Containers) == 0 {
+			return fmt.Errorf("no containers available for the selected task")
+		}
+
+		container := task.Containers[0].Name
+		if len(task.Containers) > 1 {
+			containerNames := make([]string, len(task.Containers))
+			for i, c := range task.Containers {
+				containerNames[i] = c.Name
+			}
+			i, _, err := ui.CreatePrompt(containerNames, "Select a container:")
+			if err != nil {
+				return err
+			}
+			container = containerNames[i]
+		}
+
+		checks, err := runExecPreflight(region, selectedCluster, task, container)
+		if err != nil {
+			return err
+		}
+
+		if !printExecChecks(checks) {
+			// The failures have already been reported so there is no need for the usage text
+			cmd.SilenceUsage = true
+			return fmt.Errorf("ECS Exec is not expected to work for this task")
+		}
+		fmt.Println("ECS Exec should work for this task")
+		return nil
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsExecCheckCmd)
+}
+
+type execCheck struct {
+	name        string
+	status      string // PASS, WARN or FAIL
+	detail      string
+	remediation string
+}
+
+// runExecPreflight runs each ECS Exec check against a task's container. Problems found by the checks
+// are reported in the results, an error is only returned if the task could not be checked at all.
+func runExecPreflight(region, cluster string, task aws.TaskStatus, container string) ([]execCheck, error) {
+	var checks []execCheck
+
+	if task.ExecuteCommand {
+		checks = append(checks, execCheck{name: "enableExecuteCommand", status: "PASS", detail: "enabled on the task"})
+	} else {
+		checks = append(checks, execCheck{
+			name:        "enableExecuteCommand",
+			status:      "FAIL",
+			detail:      "not enabled on the task",
+			remediation: "enable it on the service and replace its tasks with go-aws ecs enable-exec --force-new-deployment",
+		})
+	}
+
+	agentCheck := execCheck{name: "Exec agent", status: "PASS", detail: fmt.Sprintf("running in %s", container)}
+	for _, c := range task.Containers {
+		if c.Name != container {
+			continue
+		}
+		switch c.ExecAgent {
+		case "RUNNING":
+		case "":
+			agentCheck.status = "FAIL"
+			agentCheck.detail = fmt.Sprintf("no exec agent in %s", container)
+			agentCheck.remediation = "tasks only get the agent if execute command was enabled when they started, restart the task"
+		default:
+			agentCheck.status = "FAIL"
+			agentCheck.detail = fmt.Sprintf("exec agent in %s is %s", container, c.ExecAgent)
+			agentCheck.remediation = "wait for the agent to start, or restart the task if it has stopped"
+		}
+	}
+	checks = append(checks, agentCheck)
+
+	if _, err := exec.LookPath("session-manager-plugin"); err == nil {
+		checks = append(checks, execCheck{name: "session-manager-plugin", status: "PASS", detail: "installed"})
+	} else {
+		checks = append(checks, execCheck{
+			name:        "session-manager-plugin",
+			status:      "FAIL",
+			detail:      "not found on PATH",
+			remediation: "install the Session Manager plugin for the AWS CLI",
+		})
+	}
+
+	td, _, err := aws.GetTaskDefinition(region, task.TaskDefinitionArn)
+	if err != nil {
+		return nil, err
+	}
+
+	execConfig, err := aws.GetClusterExecConfig(region, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	roleArn := ""
+	if td.TaskRoleArn != nil {
+		roleArn = *td.TaskRoleArn
+	}
+	if roleArn == "" {
+		checks = append(checks, execCheck{
+			name:        "Task role",
+			status:      "FAIL",
+			detail:      "the task definition has no task role",
+			remediation: "add a task role with the ssmmessages permissions to the task definition",
+		})
+		return checks, nil
+	}
+
+	checks = append(checks, simulateExecCheck(region, roleArn, "Task role SSM permissions", ssmMessagesActions, nil,
+		"allow "+strings.Join(ssmMessagesActions, ", ")+" on the task role"))
+
+	// The exec session is encrypted with the cluster's KMS key, which the task role must be able to use
+	if execConfig.KmsKeyID != "" {
+		var resources []string
+		if strings.HasPrefix(execConfig.KmsKeyID, "arn:") {
+			resources = []string{execConfig.KmsKeyID}
+		}
+		checks = append(checks, simulateExecCheck(region, roleArn, "KMS key", []string{"kms:Decrypt"}, resources,
+			fmt.Sprintf("allow kms:Decrypt on %s for the task role", execConfig.KmsKeyID)))
+	}
+
+	partition, account := arnPartitionAndAccount(roleArn)
+	switch execConfig.Logging {
+	case "NONE":
+		checks = append(checks, execCheck{name: "Session logging", status: "PASS", detail: "disabled"})
+	case "OVERRIDE":
+		if execConfig.LogGroup != "" {
+			checks = append(checks, logGroupExecCheck(region, roleArn, partition, account, region, execConfig.LogGroup))
+			if execConfig.CloudWatchEncryption {
+				checks = append(checks, logGroupEncryptionCheck(region, execConfig.LogGroup))
+			}
+		}
+		if execConfig.S3Bucket != "" {
+			resource := fmt.Sprintf("arn:%s:s3:::%s/%s*", partition, execConfig.S3Bucket, execConfig.S3KeyPrefix)
+			checks = append(checks, simulateExecCheck(region, roleArn, "S3 session logging", []string{"s3:PutObject"}, []string{resource},
+				fmt.Sprintf("allow s3:PutObject on %s for the task role", resource)))
+			if execConfig.S3Encryption {
+				bucket := fmt.Sprintf("arn:%s:s3:::%s", partition, execConfig.S3Bucket)
+				checks = append(checks, simulateExecCheck(region, roleArn, "S3 session log encryption", []string{"s3:GetEncryptionConfiguration"}, []string{bucket},
+					fmt.Sprintf("allow s3:GetEncryptionConfiguration on %s for the task role", bucket)))
+			}
+		}
+		if execConfig.LogGroup == "" && execConfig.S3Bucket == "" {
+			checks = append(checks, execCheck{
+				name:        "Session logging",
+				status:      "WARN",
+				detail:      "logging is OVERRIDE but no log group or S3 bucket is configured",
+				remediation: "set a CloudWatch log group or S3 bucket in the cluster's execute command configuration",
+			})
+		}
+	default:
+		// By default sessions are logged to the container's own awslogs group, if it has one
+		logConfig, err := aws.GetContainerLogConfig(region, td, container)
+		if err != nil {
+			checks = append(checks, execCheck{name: "Session logging", status: "PASS", detail: "not logged as the container does not use awslogs"})
+			break
+		}
+		checks = append(checks, logGroupExecCheck(region, roleArn, partition, account, logConfig.Region, logConfig.Group))
+	}
+
+	return checks, nil
+}
+
+// logGroupExecCheck checks the task role can write session logs to a log group, which may be in another region
+func logGroupExecCheck(region, roleArn, partition, account, logRegion, group string) execCheck {
+	resource := fmt.Sprintf("arn:%s:logs:%s:%s:log-group:%s:*", partition, logRegion, account, group)
+	return simulateExecCheck(region, roleArn, "CloudWatch session logging", execLogActions, []string{resource},
+		fmt.Sprintf("allow %s on log group %s for the task role", strings.Join(execLogActions, ", "), group))
+}
+
+// logGroupEncryptionCheck checks the log group is encrypted, as the exec agent will not log to it otherwise
+func logGroupEncryptionCheck(region, group string) execCheck {
+	kmsKey, err := aws.GetLogGroupKmsKey(region, group)
+	if err != nil {
+		return execCheck{
+			name:        "CloudWatch session log encryption",
+			status:      "WARN",
+			detail:      fmt.Sprintf("could not check the encryption of log group %s: %v", group, err),
+			remediation: "check the log group has a KMS key associated with it",
+		}
+	}
+	if kmsKey == "" {
+		return execCheck{
+			name:        "CloudWatch session log encryption",
+			status:      "FAIL",
+			detail:      fmt.Sprintf("encryption is required but log group %s is not encrypted", group),
+			remediation: "associate a KMS key with the log group, or turn off CloudWatch encryption in the cluster's execute command configuration",
+		}
+	}
+	return execCheck{name: "CloudWatch session log encryption", status: "PASS", detail: fmt.Sprintf("encrypted with %s", kmsKey)}
+}
+
+// simulateExecCheck checks the task role is allowed the actions. Failing to run the simulation, usually
+// because we are not allowed iam:SimulatePrincipalPolicy, is only a warning as the role may still be fine.
+func simulateExecCheck(region, roleArn, name string, actions, resources []string, remediation string) execCheck {
+	allowed, err := aws.SimulateRoleActions(region, roleArn, actions, resources)
+	if err != nil {
+		return execCheck{
+			name:        name,
+			status:      "WARN",
+			detail:      fmt.Sprintf("could not simulate the task role's policies: %v", err),
+			remediation: "check the task role manually, or run with permission for iam:SimulatePrincipalPolicy",
+		}
+	}
+
+	var denied []string
+	for _, action := range actions {
+		if !allowed[action] {
+			denied = append(denied, action)
+		}
+	}
+	if len(denied) > 0 {
+		return execCheck{
+			name:        name,
+			status:      "FAIL",
+			detail:      fmt.Sprintf("%s denied for %s", strings.Join(denied, ", "), roleArn),
+			remediation: remediation,
+		}
+	}
+	return execCheck{name: name, status: "PASS", detail: "allowed"}
+}
+
+// printExecChecks prints each check and returns false if any of them failed
+func printExecChecks(checks []execCheck) bool {
+	passed := true
+	for _, check := range checks {
+		fmt.Printf("[%s] %s: %s\n", check.status, check.name, check.detail)
+		if check.remediation != "" && check.status != "PASS" {
+			fmt.Printf("       Fix: %s\n", check.remediation)
+		}
+		if check.status == "FAIL" {
+			passed = false
+		}
+	}
+	return passed
+}
+
+// arnPartitionAndAccount returns the partition and account ID of an ARN such as arn:aws:iam::123456789012:role/name
+func arnPartitionAndAccount(arn string) (string, string) {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 5 {
+		return "aws", ""
+	}
+	return parts[1], parts[4]
+}
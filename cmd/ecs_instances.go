@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ecsInstancesCmd represents the ecs instances command
+var ecsInstancesCmd = &cobra.Command{
+	Use:   "instances",
+	Short: "List the container instances of an EC2 backed cluster and drain, activate or SSM into one",
+	Long: `Prompts for a cluster and lists its container instances along with their EC2 instance ID,
+	status, agent version and connection, remaining CPU and memory and running task count.
+	Selecting an instance offers to start an SSM session with the host, or to drain or activate it.
+	Use with: go-aws ecs instances`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		instances, err := aws.ListContainerInstances(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		if len(instances) == 0 {
+			fmt.Println("No container instances found, the cluster may only run Fargate tasks")
+			return nil
+		}
+
+		options := make([]string, len(instances))
+		for i, instance := range instances {
+			options[i] = formatContainerInstance(instance)
+		}
+
+		i, _, err := ui.CreatePrompt(options, "Select a container instance:")
+		if err != nil {
+			return err
+		}
+		instance := instances[i]
+
+		// Only offer the state change that applies to the instance's current status
+		actions := []string{"Start an SSM session"}
+		switch instance.Status {
+		case "ACTIVE":
+			actions = append(actions, "Drain")
+		case "DRAINING":
+			actions = append(actions, "Activate")
+		}
+
+		j, _, err := ui.CreatePrompt(actions, fmt.Sprintf("Select an action for %s:", instance.EC2InstanceID))
+		if err != nil {
+			return err
+		}
+
+		if j == 0 {
+			return startSSMSession(instance.EC2InstanceID)
+		}
+
+		status, verb := "DRAINING", "Draining"
+		if actions[j] == "Activate" {
+			status, verb = "ACTIVE", "Activating"
+		}
+
+		if !skipConfirm {
+			confirmed, err := ui.Confirm(fmt.Sprintf("%s %s (%d running tasks)", actions[j], instance.EC2InstanceID, instance.RunningTasks))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		if err := aws.UpdateContainerInstancesState(region, selectedCluster, []string{instance.Arn}, status); err != nil {
+			return err
+		}
+		fmt.Printf("%s %s\n", verb, instance.EC2InstanceID)
+		return nil
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsInstancesCmd)
+
+	ecsInstancesCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+}
+
+func formatContainerInstance(instance aws.ContainerInstance) string {
+	connected := "connected"
+	if !instance.AgentConnected {
+		connected = "disconnected"
+	}
+	return fmt.Sprintf("%-19s  %-8s  agent %-7s %-12s  free cpu %d/%d  free mem %d/%d MiB  tasks %d running, %d pending",
+		instance.EC2InstanceID,
+		instance.Status,
+		instance.AgentVersion,
+		connected,
+		instance.RemainingCPU,
+		instance.RegisteredCPU,
+		instance.RemainingMemory,
+		instance.RegisteredMemory,
+		instance.RunningTasks,
+		instance.PendingTasks,
+	)
+}
@@ -0,0 +1,336 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/spf13/cobra"
+)
+
+// ecsLogsCmd represents the ecs logs command
+var ecsLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Tail the CloudWatch logs of an ECS container",
+	Long: `Prompts for a cluster, service, task and container and prints the container's logs from
+	CloudWatch Logs, using the awslogs settings in its task definition to find the log stream.
+	Choose "All tasks" to interleave the logs of every task in the service, prefixed with the
+	task ID. Use --since to choose how far back to start, --filter to only show matching events
+	and --follow to keep printing new events.
+	Use with: go-aws ecs logs`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		since, _ := cmd.Flags().GetDuration("since")
+		filter, _ := cmd.Flags().GetString("filter")
+		follow, _ := cmd.Flags().GetBool("follow")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := aws.ListTasks(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		if len(tasks) == 0 {
+			return fmt.Errorf("no running tasks found for the selected service")
+		}
+
+		statuses, taskOptions, err := describeTaskOptions(region, selectedCluster, tasks)
+		if err != nil {
+			return err
+		}
+
+		// Offer every task of the service as the first option
+		options := append([]string{"All tasks"}, taskOptions...)
+		i, _, err := ui.CreatePrompt(options, "Select a task:")
+		if err != nil {
+			return err
+		}
+
+		selectedTasks := statuses
+		if i > 0 {
+			selectedTasks = []aws.TaskStatus{statuses[i-1]}
+		}
+
+		// During a deployment tasks can run different revisions with different containers and log
+		// settings, so each task's own definition is used, loading each revision only once
+		logs := &serviceLogs{
+			region:          region,
+			cluster:         selectedCluster,
+			service:         selectedService,
+			filter:          filter,
+			showPrefix:      i == 0,
+			taskDefinitions: make(map[string]*ecstypes.TaskDefinition),
+			tasks:           make(map[string]taskStream),
+			gone:            make(map[string]bool),
+		}
+		var containerNames []string
+		for _, task := range selectedTasks {
+			taskDefinition, err := logs.taskDefinition(task.TaskDefinitionArn)
+			if err != nil {
+				return err
+			}
+			for _, container := range taskDefinition.ContainerDefinitions {
+				if !slices.Contains(containerNames, *container.Name) {
+					containerNames = append(containerNames, *container.Name)
+				}
+			}
+		}
+
+		logs.container = containerNames[0]
+		if len(containerNames) > 1 {
+			i, _, err := ui.CreatePrompt(containerNames, "Select a container:")
+			if err != nil {
+				return err
+			}
+			logs.container = containerNames[i]
+		}
+
+		if err := logs.addTasks(selectedTasks, time.Now().Add(-since), len(selectedTasks) == 1); err != nil {
+			return err
+		}
+
+		if len(logs.tailers) == 0 {
+			return fmt.Errorf("none of the selected tasks have a container named %s", logs.container)
+		}
+
+		lastRefresh := time.Now()
+		for {
+			for _, tailer := range logs.tailers {
+				if err := tailer.poll(); err != nil {
+					return err
+				}
+			}
+			if !follow {
+				return nil
+			}
+			time.Sleep(2 * time.Second)
+
+			// Pick up the tasks that replace the original ones during a deployment or restart
+			if logs.showPrefix && time.Since(lastRefresh) >= 30*time.Second {
+				if err := logs.refresh(); err != nil {
+					return err
+				}
+				lastRefresh = time.Now()
+			}
+		}
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsLogsCmd)
+
+	ecsLogsCmd.Flags().Duration("since", 10*time.Minute, "How far back to start showing logs, e.g. 30m, 2h")
+	ecsLogsCmd.Flags().String("filter", "", "Only show events matching this CloudWatch Logs filter pattern")
+	ecsLogsCmd.Flags().BoolP("follow", "f", false, "Keep printing new log events as they arrive")
+}
+
+// logTailer prints the log events of a set of streams, remembering what it has printed between polls
+type logTailer struct {
+	config      aws.ContainerLogConfig
+	streams     []string
+	streamTasks map[string]string
+	since       time.Time
+	filter      string
+	showPrefix  bool
+	seen        map[string]time.Time
+}
+
+// poll prints any events that have arrived since the last poll
+func (t *logTailer) poll() error {
+	if t.seen == nil {
+		t.seen = make(map[string]time.Time)
+	}
+	if len(t.streams) == 0 {
+		return nil
+	}
+
+	events, err := aws.FilterLogEvents(t.config.Region, t.config.Group, t.streams, t.since, t.filter)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if _, ok := t.seen[event.ID]; ok {
+			continue
+		}
+		t.seen[event.ID] = event.Timestamp
+		// Events can arrive late with the same timestamp, so the next poll starts from the last one seen
+		t.since = event.Timestamp
+		if t.showPrefix {
+			fmt.Printf("[%s] %s  %s\n", t.streamTasks[event.Stream], event.Timestamp.Local().Format(time.DateTime), event.Message)
+		} else {
+			fmt.Printf("%s  %s\n", event.Timestamp.Local().Format(time.DateTime), event.Message)
+		}
+	}
+
+	// Events older than since will not be returned again, so there is no need to remember them
+	for id, timestamp := range t.seen {
+		if timestamp.Before(t.since) {
+			delete(t.seen, id)
+		}
+	}
+	return nil
+}
+
+// serviceLogs follows one container's logs across the tasks of a service, which may run different
+// task definition revisions and so log to different groups
+type serviceLogs struct {
+	region          string
+	cluster         string
+	service         string
+	container       string
+	filter          string
+	showPrefix      bool
+	taskDefinitions map[string]*ecstypes.TaskDefinition
+	tailers         []*logTailer
+	tasks           map[string]taskStream
+	gone            map[string]bool
+}
+
+// taskStream records which tailer is following a task's log stream
+type taskStream struct {
+	tailer *logTailer
+	stream string
+}
+
+func (s *serviceLogs) taskDefinition(arn string) (*ecstypes.TaskDefinition, error) {
+	if taskDefinition, ok := s.taskDefinitions[arn]; ok {
+		return taskDefinition, nil
+	}
+	taskDefinition, _, err := aws.GetTaskDefinition(s.region, arn)
+	if err != nil {
+		return nil, err
+	}
+	s.taskDefinitions[arn] = taskDefinition
+	return taskDefinition, nil
+}
+
+// addTasks starts following the container's log stream in each task not already followed, from since.
+// New tasks get their own tailers so that starting earlier does not repeat events already printed.
+// Tasks without the container are skipped unless strict is set.
+func (s *serviceLogs) addTasks(tasks []aws.TaskStatus, since time.Time, strict bool) error {
+	var added []*logTailer
+	for _, task := range tasks {
+		if _, ok := s.tasks[task.Arn]; ok {
+			continue
+		}
+
+		taskDefinition, err := s.taskDefinition(task.TaskDefinitionArn)
+		if err != nil {
+			return err
+		}
+		logConfig, err := aws.GetContainerLogConfig(s.region, taskDefinition, s.container)
+		if err != nil {
+			if strict {
+				return err
+			}
+			// Older or newer revisions may not have this container at all
+			continue
+		}
+		stream, err := logConfig.LogStream(s.container, task.Arn)
+		if err != nil {
+			return err
+		}
+
+		// Tasks whose logs go to the same group are tailed together, mapping each log stream back to
+		// its task so interleaved output can be prefixed with the task ID
+		var tailer *logTailer
+		for _, t := range added {
+			if t.config.Group == logConfig.Group && t.config.Region == logConfig.Region {
+				tailer = t
+			}
+		}
+		if tailer == nil {
+			tailer = &logTailer{
+				config:      logConfig,
+				streamTasks: make(map[string]string),
+				since:       since,
+				filter:      s.filter,
+				showPrefix:  s.showPrefix,
+			}
+			added = append(added, tailer)
+		}
+		tailer.streams = append(tailer.streams, stream)
+		tailer.streamTasks[stream] = aws.TaskID(task.Arn)
+		s.tasks[task.Arn] = taskStream{tailer: tailer, stream: stream}
+	}
+
+	s.tailers = append(s.tailers, added...)
+	return nil
+}
+
+// refresh follows any new tasks in the service and stops following tasks that have gone. A task is only
+// dropped once it has been gone for two refreshes, so the last lines it logs while stopping are printed.
+func (s *serviceLogs) refresh() error {
+	arns, err := aws.ListTasks(s.region, s.cluster, s.service)
+	if err != nil {
+		return err
+	}
+
+	var newTasks []string
+	for _, arn := range arns {
+		if _, ok := s.tasks[arn]; !ok {
+			newTasks = append(newTasks, arn)
+		}
+	}
+	if len(newTasks) > 0 {
+		statuses, err := aws.DescribeTaskStatuses(s.region, s.cluster, newTasks)
+		if err != nil {
+			return err
+		}
+		// Start from the oldest new task so nothing it logged before it was noticed is missed
+		since := time.Now()
+		for _, status := range statuses {
+			if !status.CreatedAt.IsZero() && status.CreatedAt.Before(since) {
+				since = status.CreatedAt
+			}
+		}
+		for _, status := range statuses {
+			fmt.Printf("Following new task %s\n", aws.TaskID(status.Arn))
+		}
+		if err := s.addTasks(statuses, since, false); err != nil {
+			return err
+		}
+	}
+
+	for arn, followed := range s.tasks {
+		if slices.Contains(arns, arn) {
+			delete(s.gone, arn)
+			continue
+		}
+		if !s.gone[arn] {
+			s.gone[arn] = true
+			continue
+		}
+		followed.tailer.streams = slices.DeleteFunc(followed.tailer.streams, func(stream string) bool {
+			return stream == followed.stream
+		})
+		delete(s.gone, arn)
+		delete(s.tasks, arn)
+	}
+
+	s.tailers = slices.DeleteFunc(s.tailers, func(t *logTailer) bool {
+		return len(t.streams) == 0
+	})
+	return nil
+}
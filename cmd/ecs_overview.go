@@ -0,0 +1,122 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/spf13/cobra"
+)
+
+// ecsOverviewCmd represents the ecs overview command
+var ecsOverviewCmd = &cobra.Command{
+	Use:   "overview",
+	Short: "Show a table of every service in an ECS cluster",
+	Long: `Prompts for a cluster and shows each of its services with desired/running/pending counts,
+	deployment status, task definition revision, launch type and CPU/memory utilization over the
+	last 5 minutes. Services that are not at steady state are marked with a !.
+	Use with: go-aws ecs overview`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		noMetrics, _ := cmd.Flags().GetBool("no-metrics")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		services, err := aws.ListServices(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		if len(services) == 0 {
+			fmt.Println("No services found in the selected cluster")
+			return nil
+		}
+
+		statuses, err := aws.GetServiceStatuses(region, selectedCluster, services)
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].Name < statuses[j].Name
+		})
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tSERVICE\tDESIRED\tRUNNING\tPENDING\tDEPLOYMENT\tTASK DEFINITION\tLAUNCH TYPE\tCPU\tMEMORY")
+		for _, status := range statuses {
+			marker := ""
+			if !isServiceSteady(status) {
+				marker = "!"
+			}
+
+			// Services without recent datapoints are shown without utilization rather than failing the table
+			cpu, memory := "-", "-"
+			if !noMetrics {
+				cpuUtilization, memoryUtilization, err := aws.GetServiceUtilization(region, selectedCluster, status.Arn)
+				if err == nil {
+					cpu = fmt.Sprintf("%.1f%%", cpuUtilization)
+					memory = fmt.Sprintf("%.1f%%", memoryUtilization)
+				}
+			}
+
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n",
+				marker,
+				status.Name,
+				status.Desired,
+				status.Running,
+				status.Pending,
+				deploymentSummary(status),
+				taskDefinitionRevision(status.TaskDefinition),
+				status.LaunchType,
+				cpu,
+				memory,
+			)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsOverviewCmd)
+
+	ecsOverviewCmd.Flags().Bool("no-metrics", false, "Skip the CloudWatch utilization lookups")
+}
+
+// isServiceSteady reports whether a service has a single finished deployment running all of its desired tasks
+func isServiceSteady(status aws.ServiceStatus) bool {
+	if status.Running != status.Desired || status.Pending != 0 || len(status.Deployments) != 1 {
+		return false
+	}
+	rolloutState := status.Deployments[0].RolloutState
+	return rolloutState == "" || rolloutState == "COMPLETED"
+}
+
+// deploymentSummary describes the primary deployment's rollout state and how many deployments are active
+func deploymentSummary(status aws.ServiceStatus) string {
+	for _, deployment := range status.Deployments {
+		if deployment.Status != "PRIMARY" {
+			continue
+		}
+		summary := deployment.RolloutState
+		if summary == "" {
+			summary = deployment.Status
+		}
+		if len(status.Deployments) > 1 {
+			summary = fmt.Sprintf("%s (%d active)", summary, len(status.Deployments))
+		}
+		return summary
+	}
+	return "-"
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ecsRedeployCmd represents the ecs redeploy command
+var ecsRedeployCmd = &cobra.Command{
+	Use:   "redeploy",
+	Short: "Force a new deployment of an ECS service and watch it until it is stable",
+	Long: `Prompts for a cluster and service and forces a new deployment so that tasks are replaced,
+	e.g. to pick up new secrets. The rollout is then tracked until it completes or fails, in
+	which case go-aws exits with a non-zero status.
+	Use with: go-aws ecs redeploy`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		if !skipConfirm {
+			status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+			if err != nil {
+				return err
+			}
+			confirmed, err := ui.Confirm(fmt.Sprintf("Force a new deployment of %s", status.Name))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		startTime := time.Now()
+		deploymentID, err := aws.ForceNewDeployment(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Started deployment %s\n", deploymentID)
+
+		if noWait {
+			return nil
+		}
+
+		return watchRollout(region, selectedCluster, selectedService, deploymentID, startTime, timeout)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsRedeployCmd)
+
+	ecsRedeployCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	ecsRedeployCmd.Flags().Bool("no-wait", false, "Return as soon as the deployment has started")
+	ecsRedeployCmd.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the rollout to finish")
+}
+
+// watchRollout polls the service until the given deployment completes, returning an error if it fails or times out
+func watchRollout(region, cluster, service, deploymentID string, since time.Time, timeout time.Duration) error {
+	seen := make(map[string]bool)
+	lastProgress := make(map[string]string)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		status, err := aws.GetServiceStatus(region, cluster, service)
+		if err != nil {
+			return err
+		}
+
+		printNewServiceEvents(status.Events, since, seen)
+
+		var deployment *aws.Deployment
+		for i, d := range status.Deployments {
+			// Only print a deployment's progress when it changes to keep the output readable
+			progress := fmt.Sprintf("%-8s %-11s running %d/%d, pending %d, failed %d  %s",
+				d.Status,
+				d.RolloutState,
+				d.Running,
+				d.Desired,
+				d.Pending,
+				d.Failed,
+				d.TaskDefinition,
+			)
+			if lastProgress[d.ID] != progress {
+				fmt.Printf("%s  %s\n", d.ID, progress)
+				lastProgress[d.ID] = progress
+			}
+			if d.ID == deploymentID {
+				deployment = &status.Deployments[i]
+			}
+		}
+
+		if deployment == nil {
+			return fmt.Errorf("deployment %s is no longer listed on %s", deploymentID, status.Name)
+		}
+
+		switch deployment.RolloutState {
+		case "COMPLETED":
+			fmt.Printf("Deployment %s completed\n", deploymentID)
+			return nil
+		case "FAILED":
+			if status.CircuitBreakerEnabled {
+				fmt.Printf("Circuit breaker tripped (rollback enabled: %t)\n", status.CircuitBreakerRollback)
+			}
+			return fmt.Errorf("deployment %s failed: %s", deploymentID, deployment.RolloutStateReason)
+		case "":
+			// Services without a rollout state are stable once the old deployments have drained
+			if len(status.Deployments) == 1 && deployment.Running == deployment.Desired && deployment.Pending == 0 {
+				fmt.Printf("Deployment %s is stable\n", deploymentID)
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for deployment %s", timeout, deploymentID)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ecsRollbackCmd represents the ecs rollback command
+var ecsRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Roll an ECS service back to a previous task definition revision",
+	Long: `Prompts for a cluster and service and lists the recent revisions of the service's task
+	definition with their images and registration dates. The chosen revision is compared with the
+	current one and, after confirmation, the service is updated to use it and the rollout watched.
+	Use with: go-aws ecs rollback`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		current, _, err := aws.GetTaskDefinition(region, status.TaskDefinition)
+		if err != nil {
+			return err
+		}
+
+		// Fetch one extra revision as the current revision is left out of the prompt
+		revisions, err := aws.ListTaskDefinitionRevisions(region, *current.Family, limit+1)
+		if err != nil {
+			return err
+		}
+
+		var candidates []aws.TaskDefinitionRevision
+		var options []string
+		for _, revision := range revisions {
+			if revision.Revision == current.Revision {
+				continue
+			}
+			candidates = append(candidates, revision)
+			options = append(options, formatTaskDefinitionRevision(*current.Family, revision))
+		}
+
+		if len(candidates) == 0 {
+			fmt.Printf("No other active revisions of %s found\n", *current.Family)
+			return nil
+		}
+
+		fmt.Printf("Current revision: %s:%d\n", *current.Family, current.Revision)
+		i, _, err := ui.CreatePrompt(options, "Select a revision to roll back to:")
+		if err != nil {
+			return err
+		}
+
+		selectedRevision := candidates[i]
+		target, _, err := aws.GetTaskDefinition(region, selectedRevision.Arn)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Changes from %s:%d to %s:%d:\n", *current.Family, current.Revision, *target.Family, target.Revision)
+		diff := aws.DiffTaskDefinitions(current, target)
+		if len(diff) == 0 {
+			fmt.Println("  no differences")
+		}
+		for _, line := range diff {
+			fmt.Printf("  %s\n", line)
+		}
+
+		if !skipConfirm {
+			confirmed, err := ui.Confirm(fmt.Sprintf("Roll %s back to revision %d", status.Name, target.Revision))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		startTime := time.Now()
+		deploymentID, err := aws.UpdateServiceTaskDefinition(region, selectedCluster, selectedService, selectedRevision.Arn)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Started deployment %s\n", deploymentID)
+
+		if noWait {
+			return nil
+		}
+
+		return watchRollout(region, selectedCluster, selectedService, deploymentID, startTime, timeout)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsRollbackCmd)
+
+	ecsRollbackCmd.Flags().Int("limit", 10, "How many recent revisions to offer")
+	ecsRollbackCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	ecsRollbackCmd.Flags().Bool("no-wait", false, "Return as soon as the deployment has started")
+	ecsRollbackCmd.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the rollout to finish")
+}
+
+func formatTaskDefinitionRevision(family string, revision aws.TaskDefinitionRevision) string {
+	// Drop the registry and repository path so the tags fit in the prompt
+	images := make([]string, len(revision.Images))
+	for i, image := range revision.Images {
+		container, ref, _ := strings.Cut(image, "=")
+		images[i] = fmt.Sprintf("%s=%s", container, ref[strings.LastIndex(ref, "/")+1:])
+	}
+	return fmt.Sprintf("%s:%-4d  %s  %s",
+		family,
+		revision.Revision,
+		revision.RegisteredAt.Local().Format("2006-01-02 15:04"),
+		strings.Join(images, ", "),
+	)
+}
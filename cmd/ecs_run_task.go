@@ -0,0 +1,257 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/spf13/cobra"
+)
+
+// ecsRunTaskCmd represents the ecs run-task command
+var ecsRunTaskCmd = &cobra.Command{
+	Use:   "run-task",
+	Short: "Run a one-off task cloned from an ECS service",
+	Long: `Prompts for a cluster and service and starts a one-off task from the service's task
+	definition, network configuration and launch type or capacity provider strategy. The command
+	and environment of one container can be overridden. The container's logs are streamed until
+	the task stops and go-aws exits with the container's exit code.
+	Use with: go-aws ecs run-task --command "bin/rails db:migrate" --env RAILS_ENV=production`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		command, _ := cmd.Flags().GetString("command")
+		envFlags, _ := cmd.Flags().GetStringArray("env")
+		selectedContainer, _ := cmd.Flags().GetString("container")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+		overrides := aws.TaskOverrides{
+			Environment: make(map[string]string),
+		}
+
+		if command != "" {
+			var err error
+			overrides.Command, err = splitCommand(command)
+			if err != nil {
+				return fmt.Errorf("invalid --command: %w", err)
+			}
+		}
+
+		for _, flag := range envFlags {
+			name, value, ok := strings.Cut(flag, "=")
+			if !ok || name == "" {
+				return fmt.Errorf("invalid --env %q, expected NAME=value", flag)
+			}
+			overrides.Environment[name] = value
+		}
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		taskDefinition, _, err := aws.GetTaskDefinition(region, status.TaskDefinition)
+		if err != nil {
+			return err
+		}
+
+		containerNames := make([]string, len(taskDefinition.ContainerDefinitions))
+		for i, container := range taskDefinition.ContainerDefinitions {
+			containerNames[i] = *container.Name
+		}
+
+		// The overrides, logs and exit code all apply to a single container
+		if selectedContainer == "" {
+			selectedContainer = containerNames[0]
+			if len(containerNames) > 1 {
+				i, _, err := ui.CreatePrompt(containerNames, "Select a container:")
+				if err != nil {
+					return err
+				}
+				selectedContainer = containerNames[i]
+			}
+		}
+		overrides.Container = selectedContainer
+
+		fmt.Printf("Task definition: %s\n", status.TaskDefinition)
+		if len(overrides.Command) > 0 {
+			fmt.Printf("Command:         %s\n", strings.Join(overrides.Command, " "))
+		}
+		// Sort the variables so the summary reads the same on every run
+		names := make([]string, 0, len(overrides.Environment))
+		for name := range overrides.Environment {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("Env:             %s=%s\n", name, overrides.Environment[name])
+		}
+
+		if !skipConfirm {
+			confirmed, err := ui.Confirm(fmt.Sprintf("Run a one-off %s task", status.Name))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		taskArn, err := aws.RunServiceTask(region, selectedCluster, selectedService, overrides)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Started task %s\n", aws.TaskID(taskArn))
+
+		exitCode, err := followTaskToCompletion(region, selectedCluster, taskArn, taskDefinition, selectedContainer)
+		if err != nil {
+			return err
+		}
+
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsRunTaskCmd)
+
+	ecsRunTaskCmd.Flags().String("command", "", "Command to run instead of the container's default, e.g. \"bin/migrate --all\"")
+	ecsRunTaskCmd.Flags().StringArray("env", nil, "Environment variable to set as NAME=value, can be repeated")
+	ecsRunTaskCmd.Flags().String("container", "", "Container to override and follow (prompted for if not set)")
+	ecsRunTaskCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+}
+
+// followTaskToCompletion streams a container's logs until its task stops and returns the container's exit code
+func followTaskToCompletion(region, cluster, taskArn string, taskDefinition *ecstypes.TaskDefinition, container string) (int, error) {
+	var tailer *logTailer
+	logConfig, err := aws.GetContainerLogConfig(region, taskDefinition, container)
+	if err == nil {
+		var stream string
+		stream, err = logConfig.LogStream(container, taskArn)
+		if err == nil {
+			tailer = &logTailer{
+				config:  logConfig,
+				streams: []string{stream},
+				since:   time.Now().Add(-time.Minute),
+			}
+		}
+	}
+	if err != nil {
+		fmt.Printf("Logs are unavailable: %v\n", err)
+	}
+
+	lastStatus := ""
+	for {
+		statuses, err := aws.DescribeTaskStatuses(region, cluster, []string{taskArn})
+		if err != nil {
+			return 0, err
+		}
+		if len(statuses) == 0 {
+			return 0, fmt.Errorf("task %s not found", aws.TaskID(taskArn))
+		}
+
+		task := statuses[0]
+		if task.LastStatus != lastStatus {
+			fmt.Printf("Task is %s\n", task.LastStatus)
+			lastStatus = task.LastStatus
+		}
+
+		// The log stream only exists once the container has started, so errors before then are expected
+		if tailer != nil && task.LastStatus != "PROVISIONING" && task.LastStatus != "PENDING" {
+			if err := tailer.poll(); err != nil && task.LastStatus == "STOPPED" {
+				fmt.Printf("Unable to read logs: %v\n", err)
+			}
+		}
+
+		if task.LastStatus == "STOPPED" {
+			fmt.Printf("Stopped reason: %s\n", task.StoppedReason)
+			for _, c := range task.Containers {
+				if c.Name != container {
+					continue
+				}
+				if c.ExitCode == nil {
+					return 0, fmt.Errorf("container %s stopped without an exit code: %s", container, c.Reason)
+				}
+				fmt.Printf("Container %s exited with code %d\n", container, *c.ExitCode)
+				return int(*c.ExitCode), nil
+			}
+			return 0, fmt.Errorf("container %s not found in task", container)
+		}
+
+		time.Sleep(3 * time.Second)
+	}
+}
+
+// splitCommand splits a command line into words, honouring single and double quotes and backslash escapes
+func splitCommand(command string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
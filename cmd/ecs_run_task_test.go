@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", command: "", want: nil},
+		{name: "only whitespace", command: " \t\n ", want: nil},
+		{name: "plain words", command: "bin/migrate --all", want: []string{"bin/migrate", "--all"}},
+		{name: "repeated whitespace", command: "  a \t b\n c  ", want: []string{"a", "b", "c"}},
+		{name: "double quotes", command: `echo "hello world"`, want: []string{"echo", "hello world"}},
+		{name: "single quotes", command: `echo 'hello world'`, want: []string{"echo", "hello world"}},
+		{name: "empty quotes", command: `echo "" ''`, want: []string{"echo", "", ""}},
+		{name: "quotes inside a word", command: `--name="a b"c`, want: []string{"--name=a bc"}},
+		{name: "other quote kept", command: `echo "it's" '"x"'`, want: []string{"echo", "it's", `"x"`}},
+		{name: "escaped space", command: `touch a\ b`, want: []string{"touch", "a b"}},
+		{name: "escaped quote", command: `echo \"x\"`, want: []string{"echo", `"x"`}},
+		{name: "escape in double quotes", command: `echo "a\"b"`, want: []string{"echo", `a"b`}},
+		{name: "backslash literal in single quotes", command: `echo 'a\b'`, want: []string{"echo", `a\b`}},
+		{name: "escaped backslash", command: `echo \\`, want: []string{"echo", `\`}},
+		{name: "unterminated double quote", command: `echo "oops`, wantErr: true},
+		{name: "unterminated single quote", command: `echo 'oops`, wantErr: true},
+		{name: "trailing backslash", command: `echo \`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitCommand(tt.command)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("splitCommand(%q) = %q, want an error", tt.command, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("splitCommand(%q) returned error: %v", tt.command, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("splitCommand(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ecsScaleCmd represents the ecs scale command
+var ecsScaleCmd = &cobra.Command{
+	Use:   "scale",
+	Short: "Change the desired count of an ECS service",
+	Long: `Prompts for a cluster and service, updates the service's desired count after confirmation
+	and then waits until the running count matches, printing progress and service events.
+	Use with: go-aws ecs scale --count 3`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		skipConfirm, _ := cmd.Flags().GetBool("yes")
+		noWait, _ := cmd.Flags().GetBool("no-wait")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s: desired %d, running %d, pending %d\n", status.Name, status.Desired, status.Running, status.Pending)
+
+		// Prompt for the new count if it was not given as a flag
+		count, _ := cmd.Flags().GetInt32("count")
+		if !cmd.Flags().Changed("count") {
+			input, err := ui.CreateInputPrompt("New desired count", func(input string) error {
+				n, err := strconv.ParseInt(input, 10, 32)
+				if err != nil || n < 0 {
+					return fmt.Errorf("desired count must be a whole number of zero or more")
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			n, _ := strconv.ParseInt(input, 10, 32)
+			count = int32(n)
+		}
+
+		if count < 0 {
+			return fmt.Errorf("desired count must be zero or more")
+		}
+
+		if count == status.Desired {
+			fmt.Printf("%s is already at a desired count of %d\n", status.Name, count)
+			return nil
+		}
+
+		if !skipConfirm {
+			confirmed, err := ui.Confirm(fmt.Sprintf("Scale %s from %d to %d", status.Name, status.Desired, count))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+
+		startTime := time.Now()
+		if err := aws.UpdateServiceDesiredCount(region, selectedCluster, selectedService, count); err != nil {
+			return err
+		}
+		fmt.Printf("Desired count of %s set to %d\n", status.Name, count)
+
+		if noWait {
+			return nil
+		}
+
+		return waitForRunningCount(region, selectedCluster, selectedService, count, startTime, timeout)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsScaleCmd)
+
+	ecsScaleCmd.Flags().Int32("count", 0, "The new desired count, prompted for if not set")
+	ecsScaleCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	ecsScaleCmd.Flags().Bool("no-wait", false, "Return as soon as the service has been updated")
+	ecsScaleCmd.Flags().Duration("timeout", 10*time.Minute, "How long to wait for the running count to match")
+}
+
+// waitForRunningCount polls the service until its running count matches desired, printing new events as they arrive
+func waitForRunningCount(region, cluster, service string, desired int32, since time.Time, timeout time.Duration) error {
+	seen := make(map[string]bool)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		status, err := aws.GetServiceStatus(region, cluster, service)
+		if err != nil {
+			return err
+		}
+
+		printNewServiceEvents(status.Events, since, seen)
+		fmt.Printf("Running %d/%d, pending %d\n", status.Running, desired, status.Pending)
+
+		if status.Running == desired && status.Pending == 0 {
+			fmt.Printf("%s is running %d tasks\n", status.Name, desired)
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for %s to reach %d running tasks", timeout, status.Name, desired)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// ecsStopTaskCmd represents the ecs stop-task command
+var ecsStopTaskCmd = &cobra.Command{
+	Use:   "stop-task",
+	Short: "Stop one or more tasks of an ECS service",
+	Long: `Prompts for a cluster, service and any number of its tasks and stops them after
+	confirmation. The service scheduler will launch replacements as usual.
+	Use with: go-aws ecs stop-task --reason "wedged"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return stopServiceTasks(cmd, false)
+	},
+}
+
+// ecsRestartTaskCmd represents the ecs restart-task command
+var ecsRestartTaskCmd = &cobra.Command{
+	Use:     "restart-task",
+	Aliases: []string{"restart"},
+	Short:   "Stop tasks of an ECS service and wait for healthy replacements",
+	Long: `Works like stop-task but then waits for the service scheduler to launch a replacement
+	for each stopped task and reports the replacements' status and health before returning.
+	Use with: go-aws ecs restart-task`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return stopServiceTasks(cmd, true)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsStopTaskCmd)
+	ecsCmd.AddCommand(ecsRestartTaskCmd)
+
+	for _, c := range []*cobra.Command{ecsStopTaskCmd, ecsRestartTaskCmd} {
+		c.Flags().String("reason", "Stopped by go-aws", "Reason recorded against the stopped tasks")
+		c.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt")
+	}
+	ecsRestartTaskCmd.Flags().Duration("timeout", 10*time.Minute, "How long to wait for replacement tasks to become healthy")
+}
+
+func stopServiceTasks(cmd *cobra.Command, restart bool) error {
+	region := os.Getenv("AWS_DEFAULT_REGION")
+	if region == "" {
+		region = "eu-west-1" // Default region if the environment variable is not set
+	}
+
+	reason, _ := cmd.Flags().GetString("reason")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	selectedCluster, err := selectCluster(region)
+	if err != nil {
+		return err
+	}
+
+	selectedService, err := selectService(region, selectedCluster)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := aws.ListTasks(region, selectedCluster, selectedService)
+	if err != nil {
+		return err
+	}
+
+	if len(tasks) == 0 {
+		return fmt.Errorf("no running tasks found for the selected service")
+	}
+
+	statuses, options, err := describeTaskOptions(region, selectedCluster, tasks)
+	if err != nil {
+		return err
+	}
+
+	indexes, err := ui.CreateMultiSelectPrompt(options, "Select tasks to stop:")
+	if err != nil {
+		return err
+	}
+
+	if len(indexes) == 0 {
+		fmt.Println("No tasks selected")
+		return nil
+	}
+
+	selectedTasks := make([]string, len(indexes))
+	for i, index := range indexes {
+		selectedTasks[i] = statuses[index].Arn
+	}
+
+	if !skipConfirm {
+		confirmed, err := ui.Confirm(fmt.Sprintf("Stop %d task(s)", len(selectedTasks)))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println("Aborted")
+			return nil
+		}
+	}
+
+	for _, task := range selectedTasks {
+		if err := aws.StopTask(region, selectedCluster, task, reason); err != nil {
+			return err
+		}
+		fmt.Printf("Stopping task %s\n", aws.TaskID(task))
+	}
+
+	if !restart {
+		return nil
+	}
+
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	return waitForReplacementTasks(region, selectedCluster, selectedService, tasks, len(selectedTasks), timeout)
+}
+
+// waitForReplacementTasks waits until count tasks that were not in existing are running and healthy.
+// Tasks without a container health check always report UNKNOWN health so they are accepted once running.
+func waitForReplacementTasks(region, cluster, service string, existing []string, count int, timeout time.Duration) error {
+	status, err := aws.GetServiceStatus(region, cluster, service)
+	if err != nil {
+		return err
+	}
+
+	taskDefinition, _, err := aws.GetTaskDefinition(region, status.TaskDefinition)
+	if err != nil {
+		return err
+	}
+
+	hasHealthCheck := false
+	for _, container := range taskDefinition.ContainerDefinitions {
+		if container.HealthCheck != nil {
+			hasHealthCheck = true
+			break
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, task := range existing {
+		known[task] = true
+	}
+
+	lastReported := make(map[string]string)
+	deadline := time.Now().Add(timeout)
+
+	fmt.Printf("Waiting for %d replacement task(s)\n", count)
+	for {
+		tasks, err := aws.ListTasks(region, cluster, service)
+		if err != nil {
+			return err
+		}
+
+		var replacements []string
+		for _, task := range tasks {
+			if !known[task] {
+				replacements = append(replacements, task)
+			}
+		}
+
+		ready := 0
+		if len(replacements) > 0 {
+			statuses, err := aws.DescribeTaskStatuses(region, cluster, replacements)
+			if err != nil {
+				return err
+			}
+			for _, status := range statuses {
+				report := fmt.Sprintf("%s %s", status.LastStatus, status.HealthStatus)
+				if lastReported[status.ID] != report {
+					fmt.Printf("  %s  %s\n", status.ID, report)
+					lastReported[status.ID] = report
+				}
+				if status.HealthStatus == "UNHEALTHY" {
+					return fmt.Errorf("replacement task %s is unhealthy", status.ID)
+				}
+				if status.LastStatus == "RUNNING" && (status.HealthStatus == "HEALTHY" || !hasHealthCheck) {
+					ready++
+				}
+			}
+		}
+
+		if ready >= count {
+			fmt.Println("Replacement tasks are running")
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for replacement tasks", timeout)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
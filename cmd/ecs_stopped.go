@@ -0,0 +1,118 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/spf13/cobra"
+)
+
+// ecsStoppedCmd represents the ecs stopped command
+var ecsStoppedCmd = &cobra.Command{
+	Use:   "stopped",
+	Short: "Show recently stopped tasks of an ECS service grouped by stop reason",
+	Long: `Prompts for a cluster and service and lists the tasks that have recently stopped, grouped
+	by their stopped reason. Each task shows when it stopped and each of its containers' exit code,
+	reason and image digest. Use --all to include every service in the cluster. ECS only keeps
+	stopped tasks for a short time, typically around an hour.
+	Use with: go-aws ecs stopped`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		all, _ := cmd.Flags().GetBool("all")
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		// An empty service lists the stopped tasks of the whole cluster
+		selectedService := ""
+		if !all {
+			selectedService, err = selectService(region, selectedCluster)
+			if err != nil {
+				return err
+			}
+		}
+
+		taskArns, err := aws.ListStoppedTasks(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		if len(taskArns) == 0 {
+			fmt.Println("No recently stopped tasks found")
+			return nil
+		}
+
+		tasks, err := aws.DescribeTaskStatuses(region, selectedCluster, taskArns)
+		if err != nil {
+			return err
+		}
+
+		groups := make(map[string][]aws.TaskStatus)
+		for _, task := range tasks {
+			groups[task.StoppedReason] = append(groups[task.StoppedReason], task)
+		}
+
+		// Show the most common reasons first and the most recent tasks first within each reason
+		reasons := make([]string, 0, len(groups))
+		for reason, group := range groups {
+			reasons = append(reasons, reason)
+			sort.Slice(group, func(i, j int) bool {
+				return group[i].StoppedAt.After(group[j].StoppedAt)
+			})
+		}
+		sort.Slice(reasons, func(i, j int) bool {
+			if len(groups[reasons[i]]) != len(groups[reasons[j]]) {
+				return len(groups[reasons[i]]) > len(groups[reasons[j]])
+			}
+			return reasons[i] < reasons[j]
+		})
+
+		for _, reason := range reasons {
+			fmt.Printf("\n%s (%d)\n", valueOrUnknown(reason), len(groups[reason]))
+			for _, task := range groups[reason] {
+				fmt.Printf("  %s  stopped %s\n", task.ID, formatStoppedAt(task.StoppedAt))
+				for _, container := range task.Containers {
+					exitCode := "-"
+					if container.ExitCode != nil {
+						exitCode = fmt.Sprint(*container.ExitCode)
+					}
+					fmt.Printf("    %-20s exit %-4s %s\n", container.Name, exitCode, container.Reason)
+					fmt.Printf("    %-20s %s %s\n", "", container.Image, container.ImageDigest)
+				}
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsStoppedCmd)
+
+	ecsStoppedCmd.Flags().BoolP("all", "a", false, "Show stopped tasks for every service in the cluster")
+}
+
+func formatStoppedAt(stoppedAt time.Time) string {
+	if stoppedAt.IsZero() {
+		return "(stopping)"
+	}
+	return fmt.Sprintf("%s (%s ago)", stoppedAt.Local().Format(time.DateTime), time.Since(stoppedAt).Round(time.Second))
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "(unknown)"
+	}
+	return value
+}
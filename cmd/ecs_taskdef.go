@@ -0,0 +1,193 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/spf13/cobra"
+)
+
+// ecsTaskdefCmd represents the ecs taskdef command
+var ecsTaskdefCmd = &cobra.Command{
+	Use:   "taskdef",
+	Short: "Work with ECS task definitions",
+}
+
+// ecsTaskdefDiffCmd represents the ecs taskdef diff command
+var ecsTaskdefDiffCmd = &cobra.Command{
+	Use:   "diff [FROM TO]",
+	Short: "Compare two task definitions",
+	Long: `Shows the differences between two task definitions in containers, images, environment
+	variables, secret references, CPU/memory, ports and IAM roles.
+
+	Given two task definitions (family:revision or ARN) they are compared directly. Otherwise you
+	will be prompted for a cluster and service, and then for either another revision of its task
+	definition or the same service in another cluster. Use --other-profile and --other-region to
+	compare against a service in another account or region.
+	Use with: go-aws ecs taskdef diff [family:1 family:2]`,
+	Args: cobra.MatchAll(cobra.MaximumNArgs(2), func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			return fmt.Errorf("expected either no task definitions or two to compare")
+		}
+		return nil
+	}),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		otherProfile, _ := cmd.Flags().GetString("other-profile")
+		otherRegion, _ := cmd.Flags().GetString("other-region")
+		if otherRegion == "" {
+			otherRegion = region
+		}
+
+		if len(args) == 2 {
+			from, _, err := aws.GetTaskDefinition(region, args[0])
+			if err != nil {
+				return err
+			}
+			to, _, err := aws.GetTaskDefinition(region, args[1])
+			if err != nil {
+				return err
+			}
+			printTaskDefinitionDiff(from, to)
+			return nil
+		}
+
+		selectedCluster, err := selectCluster(region)
+		if err != nil {
+			return err
+		}
+
+		selectedService, err := selectService(region, selectedCluster)
+		if err != nil {
+			return err
+		}
+
+		status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+		if err != nil {
+			return err
+		}
+
+		from, _, err := aws.GetTaskDefinition(region, status.TaskDefinition)
+		if err != nil {
+			return err
+		}
+
+		// Comparing against another account or region only makes sense for the same service elsewhere
+		compareCluster := otherProfile != "" || otherRegion != region
+		if !compareCluster {
+			options := []string{"Another revision of this task definition", "The same service in another cluster"}
+			i, _, err := ui.CreatePrompt(options, "Compare with:")
+			if err != nil {
+				return err
+			}
+			compareCluster = i == 1
+		}
+
+		var to *ecstypes.TaskDefinition
+		if compareCluster {
+			to, err = findServiceTaskDefinition(otherRegion, otherProfile, status.Name, selectedCluster)
+		} else {
+			to, err = selectTaskDefinitionRevision(region, from)
+		}
+		if err != nil || to == nil {
+			return err
+		}
+
+		printTaskDefinitionDiff(from, to)
+		return nil
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsTaskdefCmd)
+	ecsTaskdefCmd.AddCommand(ecsTaskdefDiffCmd)
+
+	ecsTaskdefDiffCmd.Flags().String("other-profile", "", "AWS profile of the account to compare against")
+	ecsTaskdefDiffCmd.Flags().String("other-region", "", "Region to compare against (defaults to the current region)")
+}
+
+// selectTaskDefinitionRevision prompts for one of the other recent revisions of the task definition's family
+func selectTaskDefinitionRevision(region string, current *ecstypes.TaskDefinition) (*ecstypes.TaskDefinition, error) {
+	revisions, err := aws.ListTaskDefinitionRevisions(region, *current.Family, 11)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []aws.TaskDefinitionRevision
+	var options []string
+	for _, revision := range revisions {
+		if revision.Revision == current.Revision {
+			continue
+		}
+		candidates = append(candidates, revision)
+		options = append(options, formatTaskDefinitionRevision(*current.Family, revision))
+	}
+
+	if len(candidates) == 0 {
+		fmt.Printf("No other active revisions of %s found\n", *current.Family)
+		return nil, nil
+	}
+
+	i, _, err := ui.CreatePrompt(options, "Select a revision:")
+	if err != nil {
+		return nil, err
+	}
+
+	taskDefinition, _, err := aws.GetTaskDefinition(region, candidates[i].Arn)
+	return taskDefinition, err
+}
+
+// findServiceTaskDefinition returns the task definition of the service with the given name in another
+// cluster, prompting for the cluster if more than one has it. The profile selects another account.
+func findServiceTaskDefinition(region, profile, serviceName, currentCluster string) (*ecstypes.TaskDefinition, error) {
+	found, err := aws.FindServiceTaskDefinitions(region, profile, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []aws.ServiceTaskDefinition
+	var options []string
+	for _, service := range found {
+		if service.Cluster == currentCluster {
+			continue
+		}
+		candidates = append(candidates, service)
+		options = append(options, service.Cluster[strings.LastIndex(service.Cluster, "/")+1:])
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("service %s not found in any other cluster", serviceName)
+	}
+	if len(candidates) == 1 {
+		return candidates[0].TaskDefinition, nil
+	}
+
+	i, _, err := ui.CreatePrompt(options, "Select a cluster:")
+	if err != nil {
+		return nil, err
+	}
+	return candidates[i].TaskDefinition, nil
+}
+
+func printTaskDefinitionDiff(from, to *ecstypes.TaskDefinition) {
+	fmt.Printf("--- %s\n+++ %s\n", *from.TaskDefinitionArn, *to.TaskDefinitionArn)
+	diff := aws.DiffTaskDefinitions(from, to)
+	if len(diff) == 0 {
+		fmt.Println("No differences")
+		return
+	}
+	for _, line := range diff {
+		fmt.Println(line)
+	}
+}
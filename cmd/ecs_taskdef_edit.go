@@ -0,0 +1,196 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/CharonWare/go-aws/internal/ui"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/spf13/cobra"
+)
+
+// ecsTaskdefEditCmd represents the ecs taskdef edit command
+var ecsTaskdefEditCmd = &cobra.Command{
+	Use:   "edit [TASK_DEFINITION]",
+	Short: "Edit a task definition in $EDITOR and register it as a new revision",
+	Long: `Downloads a task definition, strips its read-only fields and opens it in $EDITOR. When
+	the editor is closed the document is validated and registered as a new revision.
+
+	Given a task definition (family, family:revision or ARN) that one is edited. Otherwise you will
+	be prompted for a cluster and service, the service's current task definition is edited and
+	you can choose to update the service to the new revision.
+	Use with: go-aws ecs taskdef edit [family:revision]`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		updateService, _ := cmd.Flags().GetBool("update-service")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		if format != "yaml" && format != "json" {
+			return fmt.Errorf("--format must be yaml or json")
+		}
+
+		var selectedCluster, selectedService, taskDefinitionName string
+		if len(args) > 0 {
+			taskDefinitionName = args[0]
+		} else {
+			var err error
+			selectedCluster, err = selectCluster(region)
+			if err != nil {
+				return err
+			}
+
+			selectedService, err = selectService(region, selectedCluster)
+			if err != nil {
+				return err
+			}
+
+			status, err := aws.GetServiceStatus(region, selectedCluster, selectedService)
+			if err != nil {
+				return err
+			}
+			taskDefinitionName = status.TaskDefinition
+		}
+
+		current, tags, err := aws.GetTaskDefinition(region, taskDefinitionName)
+		if err != nil {
+			return err
+		}
+
+		// NewRegisterInput leaves out the reserved aws: tags so they never reach the document being edited
+		original, err := aws.MarshalRegisterInput(aws.NewRegisterInput(current, tags), format)
+		if err != nil {
+			return err
+		}
+
+		// Keep reopening the editor until the document is valid or the user gives up
+		document := original
+		for {
+			document, err = editInEditor(document, format)
+			if err != nil {
+				return err
+			}
+
+			if bytes.Equal(document, original) {
+				fmt.Println("No changes made")
+				return nil
+			}
+
+			input, err := aws.UnmarshalRegisterInput(document, format)
+			if err == nil {
+				return registerEditedTaskDefinition(region, selectedCluster, selectedService, current, input, updateService, timeout)
+			}
+
+			fmt.Println(err)
+			again, err := ui.Confirm("Edit again")
+			if err != nil {
+				return err
+			}
+			if !again {
+				fmt.Println("Aborted")
+				return nil
+			}
+		}
+	},
+}
+
+func init() {
+	ecsTaskdefCmd.AddCommand(ecsTaskdefEditCmd)
+
+	ecsTaskdefEditCmd.Flags().String("format", "yaml", "Document format to edit, yaml or json")
+	ecsTaskdefEditCmd.Flags().Bool("update-service", false, "Update the selected service to the new revision without asking")
+	ecsTaskdefEditCmd.Flags().Duration("timeout", 30*time.Minute, "How long to wait for the service rollout to finish")
+}
+
+// editInEditor opens the document in $EDITOR (vi if unset) and returns the saved contents
+func editInEditor(document []byte, format string) ([]byte, error) {
+	file, err := os.CreateTemp("", "go-aws-taskdef-*."+format)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(document); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unable to write temporary file: %w", err)
+	}
+	file.Close()
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// EDITOR may include arguments, e.g. "code --wait"
+	editorArgs := strings.Fields(editor)
+	cmd := exec.Command(editorArgs[0], append(editorArgs[1:], file.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor exited with error: %w", err)
+	}
+
+	edited, err := os.ReadFile(file.Name())
+	if err != nil {
+		return nil, fmt.Errorf("unable to read temporary file: %w", err)
+	}
+	return edited, nil
+}
+
+func registerEditedTaskDefinition(region, cluster, service string, current *ecstypes.TaskDefinition, input *ecs.RegisterTaskDefinitionInput, updateService bool, timeout time.Duration) error {
+	printTaskDefinitionDiff(current, aws.TaskDefinitionFromInput(input))
+
+	confirmed, err := ui.Confirm("Register this as a new revision")
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		fmt.Println("Aborted")
+		return nil
+	}
+
+	taskDefinitionArn, err := aws.RegisterTaskDefinition(region, input)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Registered %s\n", taskDefinitionArn)
+
+	// Only offer to update the service when the task definition came from one
+	if service == "" {
+		return nil
+	}
+
+	if !updateService {
+		updateService, err = ui.Confirm(fmt.Sprintf("Update %s to use it", service[strings.LastIndex(service, "/")+1:]))
+		if err != nil {
+			return err
+		}
+		if !updateService {
+			return nil
+		}
+	}
+
+	startTime := time.Now()
+	deploymentID, err := aws.UpdateServiceTaskDefinition(region, cluster, service, taskDefinitionArn)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Started deployment %s\n", deploymentID)
+
+	return watchRollout(region, cluster, service, deploymentID, startTime, timeout)
+}
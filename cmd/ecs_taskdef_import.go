@@ -0,0 +1,59 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/spf13/cobra"
+)
+
+// ecsTaskdefImportCmd represents the ecs taskdef import command
+var ecsTaskdefImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Register a task definition from an exported file",
+	Long: `Registers a new task definition revision from a JSON or YAML register input document, such
+	as one exported with go-aws ecs -t --format json or --format yaml. The format is taken
+	from the file extension unless --format is given.
+	Use with: go-aws ecs taskdef import taskdef.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		if format == "" {
+			switch filepath.Ext(args[0]) {
+			case ".yaml", ".yml":
+				format = "yaml"
+			default:
+				format = "json"
+			}
+		}
+
+		document, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("unable to read %s: %w", args[0], err)
+		}
+
+		taskDefinitionArn, err := aws.ImportTaskDefinition(region, document, format)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Registered %s\n", taskDefinitionArn)
+		return nil
+	},
+}
+
+func init() {
+	ecsTaskdefCmd.AddCommand(ecsTaskdefImportCmd)
+
+	ecsTaskdefImportCmd.Flags().String("format", "", "Document format, json or yaml (default from the file extension)")
+}
@@ -0,0 +1,182 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/aws"
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report any unhealthy ECS services in the region",
+	Long: `Scans every ECS cluster in the region, or in every region with --all-regions, and reports
+	services whose running count does not match their desired count, deployments stuck in progress,
+	deployments failed by the circuit breaker and services with crash-looping tasks. Exits with a
+	non-zero status if anything is found.
+	Use with: go-aws status`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		region := os.Getenv("AWS_DEFAULT_REGION")
+		if region == "" {
+			region = "eu-west-1" // Default region if the environment variable is not set
+		}
+
+		allRegions, _ := cmd.Flags().GetBool("all-regions")
+		stuckAfter, _ := cmd.Flags().GetDuration("stuck-after")
+		crashThreshold, _ := cmd.Flags().GetInt("crash-threshold")
+
+		regions := []string{region}
+		if allRegions {
+			var err error
+			regions, err = aws.ListRegions(region)
+			if err != nil {
+				return err
+			}
+			sort.Strings(regions)
+		}
+
+		var problems []string
+		servicesChecked := 0
+		regionsChecked := 0
+		for _, r := range regions {
+			regionProblems, checked, err := checkRegionStatus(r, stuckAfter, crashThreshold)
+			if err != nil {
+				if !allRegions {
+					return fmt.Errorf("%s: %w", r, err)
+				}
+				// Regions can be denied by an SCP, so one failing region should not stop the whole scan
+				fmt.Fprintf(os.Stderr, "Warning: skipping %s: %v\n", r, err)
+				continue
+			}
+			problems = append(problems, regionProblems...)
+			servicesChecked += checked
+			regionsChecked++
+		}
+
+		if regionsChecked == 0 {
+			return fmt.Errorf("unable to check any of the %d regions", len(regions))
+		}
+
+		if len(problems) == 0 {
+			fmt.Printf("All clear: %d services checked in %d region(s)\n", servicesChecked, regionsChecked)
+			return nil
+		}
+
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+
+		// The problems have already been reported so there is no need for the usage text
+		cmd.SilenceUsage = true
+		return fmt.Errorf("found %d problem(s) across %d services", len(problems), servicesChecked)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().Bool("all-regions", false, "Check every region enabled for the account")
+	statusCmd.Flags().Duration("stuck-after", 30*time.Minute, "How long a deployment can be in progress before it is reported as stuck")
+	statusCmd.Flags().Int("crash-threshold", 3, "How many recently crashed tasks a service needs to be reported as crash-looping")
+}
+
+// checkRegionStatus returns a line for each problem found in the region and how many services were checked
+func checkRegionStatus(region string, stuckAfter time.Duration, crashThreshold int) ([]string, int, error) {
+	clusters, err := aws.ListClusters(region)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var problems []string
+	checked := 0
+	for _, cluster := range clusters {
+		clusterName := cluster[strings.LastIndex(cluster, "/")+1:]
+		report := func(service, problem string) {
+			problems = append(problems, fmt.Sprintf("%s  %s/%s: %s", region, clusterName, service, problem))
+		}
+
+		services, err := aws.ListServices(region, cluster)
+		if err != nil {
+			return nil, 0, err
+		}
+		statuses, err := aws.GetServiceStatuses(region, cluster, services)
+		if err != nil {
+			return nil, 0, err
+		}
+		checked += len(statuses)
+
+		for _, status := range statuses {
+			if status.Running != status.Desired {
+				report(status.Name, fmt.Sprintf("running %d of %d desired tasks", status.Running, status.Desired))
+			}
+			for _, deployment := range status.Deployments {
+				switch {
+				case deployment.RolloutState == "FAILED":
+					reason := deployment.RolloutStateReason
+					if status.CircuitBreakerEnabled {
+						reason = "circuit breaker: " + reason
+					}
+					report(status.Name, fmt.Sprintf("deployment %s failed (%s)", deployment.ID, reason))
+				case deployment.RolloutState == "IN_PROGRESS" && time.Since(deployment.CreatedAt) > stuckAfter:
+					report(status.Name, fmt.Sprintf("deployment %s in progress for %s", deployment.ID, time.Since(deployment.CreatedAt).Round(time.Minute)))
+				}
+			}
+		}
+
+		crashes, err := countCrashedTasks(region, cluster)
+		if err != nil {
+			return nil, 0, err
+		}
+		// Sort the services so the report is in the same order on every run
+		crashedServices := make([]string, 0, len(crashes))
+		for service := range crashes {
+			crashedServices = append(crashedServices, service)
+		}
+		sort.Strings(crashedServices)
+		for _, service := range crashedServices {
+			if crashes[service] >= crashThreshold {
+				report(service, fmt.Sprintf("%d tasks crashed recently", crashes[service]))
+			}
+		}
+	}
+
+	return problems, checked, nil
+}
+
+// countCrashedTasks counts the recently stopped tasks of each service in the cluster that exited or failed on their own
+func countCrashedTasks(region, cluster string) (map[string]int, error) {
+	crashes := make(map[string]int)
+
+	taskArns, err := aws.ListStoppedTasks(region, cluster, "")
+	if err != nil || len(taskArns) == 0 {
+		return crashes, err
+	}
+
+	tasks, err := aws.DescribeTaskStatuses(region, cluster, taskArns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		// Service tasks are started in a group named service:<name>
+		service, ok := strings.CutPrefix(task.Group, "service:")
+		if !ok {
+			continue
+		}
+		crashed := task.StopCode == "EssentialContainerExited" ||
+			task.StopCode == "TaskFailedToStart" ||
+			strings.Contains(strings.ToLower(task.StoppedReason), "health check")
+		if crashed {
+			crashes[service]++
+		}
+	}
+	return crashes, nil
+}
@@ -3,12 +3,16 @@ package aws
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/CharonWare/go-aws/internal/shared"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	asgtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/robfig/cron/v3"
 )
 
 type ASG struct {
@@ -17,6 +21,37 @@ type ASG struct {
 	MaxSize         int32
 	DesiredCapacity int32
 	AVGCPU          float64
+	Suspended       []string
+}
+
+// ScalingProcesses are the autoscaling processes that can be suspended and resumed
+var ScalingProcesses = []string{
+	"Launch",
+	"Terminate",
+	"AddToLoadBalancer",
+	"AlarmNotification",
+	"AZRebalance",
+	"HealthCheck",
+	"InstanceRefresh",
+	"ReplaceUnhealthy",
+	"ScheduledActions",
+}
+
+type ScalingActivity struct {
+	ID          string
+	StartTime   time.Time
+	Status      string
+	Cause       string
+	Description string
+}
+
+type ASGInstance struct {
+	ID                   string
+	LifecycleState       string
+	HealthStatus         string
+	AvailabilityZone     string
+	LaunchTemplate       string
+	ProtectedFromScaleIn bool
 }
 
 func DescribeASGs(region string) ([]ASG, error) {
@@ -69,6 +104,7 @@ func DescribeASGs(region string) ([]ASG, error) {
 					MaxSize:         *AutoScalingGroups.MaxSize,
 					DesiredCapacity: *AutoScalingGroups.DesiredCapacity,
 					AVGCPU:          output,
+					Suspended:       newSuspendedProcesses(AutoScalingGroups.SuspendedProcesses),
 				})
 			}
 		}
@@ -80,3 +116,482 @@ func DescribeASGs(region string) ([]ASG, error) {
 
 	return groups, nil
 }
+
+// ListASGs returns the names of every autoscaling group in the region
+func ListASGs(region string) ([]string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DescribeAutoScalingGroupsInput{}
+
+	// Use a paginator to ensure we see all the results
+	var names []string
+	paginator := autoscaling.NewDescribeAutoScalingGroupsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe autoscaling groups: %v", err)
+		}
+		for _, group := range page.AutoScalingGroups {
+			names = append(names, *group.AutoScalingGroupName)
+		}
+	}
+
+	return names, nil
+}
+
+// DescribeASGInstances returns the instances currently attached to the named autoscaling group
+func DescribeASGInstances(region, group string) ([]ASGInstance, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{group},
+	}
+
+	output, err := client.DescribeAutoScalingGroups(context.Background(), input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe autoscaling group: %v", err)
+	}
+
+	if len(output.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("autoscaling group %s not found", group)
+	}
+
+	return newASGInstances(output.AutoScalingGroups[0].Instances), nil
+}
+
+// GetSuspendedProcesses returns the names of the processes currently suspended on the group
+func GetSuspendedProcesses(region, group string) ([]string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{group},
+	}
+
+	output, err := client.DescribeAutoScalingGroups(context.Background(), input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe autoscaling group: %v", err)
+	}
+
+	if len(output.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("autoscaling group %s not found", group)
+	}
+
+	return newSuspendedProcesses(output.AutoScalingGroups[0].SuspendedProcesses), nil
+}
+
+func SuspendProcesses(region, group string, processes []string) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.SuspendProcessesInput{
+		AutoScalingGroupName: aws.String(group),
+		ScalingProcesses:     processes,
+	}
+
+	_, err = client.SuspendProcesses(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to suspend processes: %v", err)
+	}
+	return nil
+}
+
+func ResumeProcesses(region, group string, processes []string) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.ResumeProcessesInput{
+		AutoScalingGroupName: aws.String(group),
+		ScalingProcesses:     processes,
+	}
+
+	_, err = client.ResumeProcesses(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to resume processes: %v", err)
+	}
+	return nil
+}
+
+func newSuspendedProcesses(processes []asgtypes.SuspendedProcess) []string {
+	var names []string
+	for _, process := range processes {
+		names = append(names, aws.ToString(process.ProcessName))
+	}
+	return names
+}
+
+func newASGInstances(instances []asgtypes.Instance) []ASGInstance {
+	var result []ASGInstance
+	for _, instance := range instances {
+		// Instances are launched from either a launch template or a legacy launch configuration
+		launchTemplate := aws.ToString(instance.LaunchConfigurationName)
+		if instance.LaunchTemplate != nil {
+			launchTemplate = fmt.Sprintf("%s:%s",
+				aws.ToString(instance.LaunchTemplate.LaunchTemplateName),
+				aws.ToString(instance.LaunchTemplate.Version),
+			)
+		}
+		result = append(result, ASGInstance{
+			ID:                   aws.ToString(instance.InstanceId),
+			LifecycleState:       string(instance.LifecycleState),
+			HealthStatus:         aws.ToString(instance.HealthStatus),
+			AvailabilityZone:     aws.ToString(instance.AvailabilityZone),
+			LaunchTemplate:       launchTemplate,
+			ProtectedFromScaleIn: aws.ToBool(instance.ProtectedFromScaleIn),
+		})
+	}
+	return result
+}
+
+// DescribeScalingActivities returns the scaling activities for a group that started after since, oldest first
+func DescribeScalingActivities(region, group string, since time.Time) ([]ScalingActivity, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(group),
+	}
+
+	var activities []ScalingActivity
+
+	// Activities are returned newest first so we can stop paging once we pass the since time
+	paginator := autoscaling.NewDescribeScalingActivitiesPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe scaling activities: %v", err)
+		}
+		reachedSince := false
+		for _, activity := range page.Activities {
+			startTime := aws.ToTime(activity.StartTime)
+			if startTime.Before(since) {
+				reachedSince = true
+				break
+			}
+			activities = append(activities, ScalingActivity{
+				ID:          aws.ToString(activity.ActivityId),
+				StartTime:   startTime,
+				Status:      string(activity.StatusCode),
+				Cause:       aws.ToString(activity.Cause),
+				Description: aws.ToString(activity.Description),
+			})
+		}
+		if reachedSince {
+			break
+		}
+	}
+
+	sort.Slice(activities, func(i, j int) bool {
+		return activities[i].StartTime.Before(activities[j].StartTime)
+	})
+
+	return activities, nil
+}
+
+type LifecycleHook struct {
+	Name             string
+	Transition       string
+	HeartbeatTimeout int32
+	DefaultResult    string
+}
+
+func EnterStandby(region, group string, instanceIDs []string, decrement bool) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.EnterStandbyInput{
+		AutoScalingGroupName:           aws.String(group),
+		InstanceIds:                    instanceIDs,
+		ShouldDecrementDesiredCapacity: aws.Bool(decrement),
+	}
+
+	_, err = client.EnterStandby(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to move instances into standby: %v", err)
+	}
+	return nil
+}
+
+func ExitStandby(region, group string, instanceIDs []string) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.ExitStandbyInput{
+		AutoScalingGroupName: aws.String(group),
+		InstanceIds:          instanceIDs,
+	}
+
+	_, err = client.ExitStandby(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to move instances out of standby: %v", err)
+	}
+	return nil
+}
+
+func DetachInstances(region, group string, instanceIDs []string, decrement bool) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DetachInstancesInput{
+		AutoScalingGroupName:           aws.String(group),
+		InstanceIds:                    instanceIDs,
+		ShouldDecrementDesiredCapacity: aws.Bool(decrement),
+	}
+
+	_, err = client.DetachInstances(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to detach instances: %v", err)
+	}
+	return nil
+}
+
+func SetInstanceProtection(region, group string, instanceIDs []string, protected bool) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.SetInstanceProtectionInput{
+		AutoScalingGroupName: aws.String(group),
+		InstanceIds:          instanceIDs,
+		ProtectedFromScaleIn: aws.Bool(protected),
+	}
+
+	_, err = client.SetInstanceProtection(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to set instance protection: %v", err)
+	}
+	return nil
+}
+
+func DescribeLifecycleHooks(region, group string) ([]LifecycleHook, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(group),
+	}
+
+	output, err := client.DescribeLifecycleHooks(context.Background(), input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe lifecycle hooks: %v", err)
+	}
+
+	var hooks []LifecycleHook
+	for _, hook := range output.LifecycleHooks {
+		hooks = append(hooks, LifecycleHook{
+			Name:             aws.ToString(hook.LifecycleHookName),
+			Transition:       aws.ToString(hook.LifecycleTransition),
+			HeartbeatTimeout: aws.ToInt32(hook.HeartbeatTimeout),
+			DefaultResult:    aws.ToString(hook.DefaultResult),
+		})
+	}
+	return hooks, nil
+}
+
+// CompleteLifecycleAction releases an instance waiting on a lifecycle hook with a result of CONTINUE or ABANDON
+func CompleteLifecycleAction(region, group, hook, instanceID, result string) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.CompleteLifecycleActionInput{
+		AutoScalingGroupName:  aws.String(group),
+		LifecycleHookName:     aws.String(hook),
+		InstanceId:            aws.String(instanceID),
+		LifecycleActionResult: aws.String(result),
+	}
+
+	_, err = client.CompleteLifecycleAction(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to complete lifecycle action: %v", err)
+	}
+	return nil
+}
+
+type ScheduledAction struct {
+	Name            string
+	Recurrence      string
+	TimeZone        string
+	StartTime       *time.Time
+	EndTime         *time.Time
+	MinSize         *int32
+	MaxSize         *int32
+	DesiredCapacity *int32
+	NextRun         time.Time
+}
+
+func DescribeScheduledActions(region, group string) ([]ScheduledAction, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DescribeScheduledActionsInput{
+		AutoScalingGroupName: aws.String(group),
+	}
+
+	var actions []ScheduledAction
+
+	// Use a paginator to ensure we see all the results
+	paginator := autoscaling.NewDescribeScheduledActionsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe scheduled actions: %v", err)
+		}
+		for _, action := range page.ScheduledUpdateGroupActions {
+			scheduled := ScheduledAction{
+				Name:            aws.ToString(action.ScheduledActionName),
+				Recurrence:      aws.ToString(action.Recurrence),
+				TimeZone:        aws.ToString(action.TimeZone),
+				StartTime:       action.StartTime,
+				EndTime:         action.EndTime,
+				MinSize:         action.MinSize,
+				MaxSize:         action.MaxSize,
+				DesiredCapacity: action.DesiredCapacity,
+			}
+			// A next run we cannot work out is left as the zero time rather than failing the whole listing
+			scheduled.NextRun, _ = NextScheduledRun(scheduled, time.Now())
+			actions = append(actions, scheduled)
+		}
+	}
+
+	return actions, nil
+}
+
+func PutScheduledAction(region, group string, action ScheduledAction) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.PutScheduledUpdateGroupActionInput{
+		AutoScalingGroupName: aws.String(group),
+		ScheduledActionName:  aws.String(action.Name),
+		StartTime:            action.StartTime,
+		EndTime:              action.EndTime,
+		MinSize:              action.MinSize,
+		MaxSize:              action.MaxSize,
+		DesiredCapacity:      action.DesiredCapacity,
+	}
+	if action.Recurrence != "" {
+		input.Recurrence = aws.String(action.Recurrence)
+	}
+	if action.TimeZone != "" {
+		input.TimeZone = aws.String(action.TimeZone)
+	}
+
+	_, err = client.PutScheduledUpdateGroupAction(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to put scheduled action: %v", err)
+	}
+	return nil
+}
+
+func DeleteScheduledAction(region, group, name string) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := autoscaling.NewFromConfig(cfg)
+	input := &autoscaling.DeleteScheduledActionInput{
+		AutoScalingGroupName: aws.String(group),
+		ScheduledActionName:  aws.String(name),
+	}
+
+	_, err = client.DeleteScheduledAction(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to delete scheduled action: %v", err)
+	}
+	return nil
+}
+
+// ParseRecurrence validates a scheduled action's cron expression and time zone, which default to UTC like AWS
+func ParseRecurrence(recurrence, timeZone string) (cron.Schedule, *time.Location, error) {
+	location := time.UTC
+	if timeZone != "" {
+		var err error
+		location, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone %q: %v", timeZone, err)
+		}
+	}
+
+	// Only the plain five field syntax is accepted, AWS rejects descriptors such as @daily and a TZ= prefix.
+	// The parser handles the prefix whatever options it is given so it has to be checked separately.
+	if strings.HasPrefix(recurrence, "TZ=") || strings.HasPrefix(recurrence, "CRON_TZ=") {
+		return nil, nil, fmt.Errorf("invalid recurrence %q: set the time zone separately instead of using a TZ= prefix", recurrence)
+	}
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, err := parser.Parse(recurrence)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid recurrence %q: %v", recurrence, err)
+	}
+
+	return schedule, location, nil
+}
+
+// NextScheduledRun works out when a scheduled action will next run after from, returning the zero time if it never will
+func NextScheduledRun(action ScheduledAction, from time.Time) (time.Time, error) {
+	if action.Recurrence == "" {
+		if action.StartTime != nil && action.StartTime.After(from) {
+			return *action.StartTime, nil
+		}
+		return time.Time{}, nil
+	}
+
+	schedule, location, err := ParseRecurrence(action.Recurrence, action.TimeZone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Recurring actions do not fire before their start time, but can fire at it
+	if action.StartTime != nil && action.StartTime.After(from) {
+		from = action.StartTime.Add(-time.Nanosecond)
+	}
+
+	next := schedule.Next(from.In(location))
+	if action.EndTime != nil && next.After(*action.EndTime) {
+		return time.Time{}, nil
+	}
+	return next, nil
+}
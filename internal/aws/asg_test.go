@@ -0,0 +1,149 @@
+package aws
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		recurrence string
+		timeZone   string
+		wantErr    bool
+	}{
+		{recurrence: "0 20 * * *"},
+		{recurrence: "30 6 * * MON-FRI", timeZone: "Europe/London"},
+		{recurrence: "*/15 * 1,15 JAN *"},
+		{recurrence: "@daily", wantErr: true},
+		{recurrence: "@every 1h", wantErr: true},
+		{recurrence: "TZ=Europe/London 0 20 * * *", wantErr: true},
+		{recurrence: "CRON_TZ=UTC 0 20 * * *", wantErr: true},
+		{recurrence: "0 0 20 * * *", wantErr: true},
+		{recurrence: "0 20 * *", wantErr: true},
+		{recurrence: "0 25 * * *", wantErr: true},
+		{recurrence: "0 20 * * *", timeZone: "Not/AZone", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.recurrence, func(t *testing.T) {
+			_, _, err := ParseRecurrence(tt.recurrence, tt.timeZone)
+			if tt.wantErr && err == nil {
+				t.Errorf("ParseRecurrence(%q, %q) succeeded, want an error", tt.recurrence, tt.timeZone)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("ParseRecurrence(%q, %q) returned error: %v", tt.recurrence, tt.timeZone, err)
+			}
+		})
+	}
+}
+
+func TestNextScheduledRun(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	ptr := func(value string) *time.Time {
+		parsed := at(value)
+		return &parsed
+	}
+
+	from := at("2025-01-01T10:00:00Z")
+	tests := []struct {
+		name    string
+		action  ScheduledAction
+		from    time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "one-off in the future",
+			action: ScheduledAction{StartTime: ptr("2025-01-02T08:00:00Z")},
+			from:   from,
+			want:   at("2025-01-02T08:00:00Z"),
+		},
+		{
+			name:   "one-off in the past",
+			action: ScheduledAction{StartTime: ptr("2024-12-31T08:00:00Z")},
+			from:   from,
+		},
+		{
+			name:   "one-off without a start time",
+			action: ScheduledAction{},
+			from:   from,
+		},
+		{
+			name:   "recurring later today",
+			action: ScheduledAction{Recurrence: "0 20 * * *"},
+			from:   from,
+			want:   at("2025-01-01T20:00:00Z"),
+		},
+		{
+			name:   "recurring already run today",
+			action: ScheduledAction{Recurrence: "0 8 * * *"},
+			from:   from,
+			want:   at("2025-01-02T08:00:00Z"),
+		},
+		{
+			name:   "recurring in a time zone with daylight saving",
+			action: ScheduledAction{Recurrence: "0 20 * * *", TimeZone: "Europe/London"},
+			from:   at("2025-07-01T10:00:00Z"),
+			want:   at("2025-07-01T19:00:00Z"),
+		},
+		{
+			name:   "recurring not started yet",
+			action: ScheduledAction{Recurrence: "0 20 * * *", StartTime: ptr("2025-01-05T00:00:00Z")},
+			from:   from,
+			want:   at("2025-01-05T20:00:00Z"),
+		},
+		{
+			name:   "recurring starting exactly on a run",
+			action: ScheduledAction{Recurrence: "0 20 * * *", StartTime: ptr("2025-01-05T20:00:00Z")},
+			from:   from,
+			want:   at("2025-01-05T20:00:00Z"),
+		},
+		{
+			name:   "recurring start time in the past",
+			action: ScheduledAction{Recurrence: "0 20 * * *", StartTime: ptr("2024-06-01T00:00:00Z")},
+			from:   from,
+			want:   at("2025-01-01T20:00:00Z"),
+		},
+		{
+			name:   "recurring ending exactly on the next run",
+			action: ScheduledAction{Recurrence: "0 20 * * *", EndTime: ptr("2025-01-01T20:00:00Z")},
+			from:   from,
+			want:   at("2025-01-01T20:00:00Z"),
+		},
+		{
+			name:   "recurring ended before the next run",
+			action: ScheduledAction{Recurrence: "0 20 * * *", EndTime: ptr("2025-01-01T19:59:00Z")},
+			from:   from,
+		},
+		{
+			name:    "invalid recurrence",
+			action:  ScheduledAction{Recurrence: "@daily"},
+			from:    from,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextScheduledRun(tt.action, tt.from)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NextScheduledRun() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextScheduledRun() returned error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextScheduledRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,8 +2,8 @@ package aws
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
 
 type describeCluster struct {
@@ -31,6 +32,139 @@ type describeService struct {
 	AVGCPU     float64
 }
 
+type ServiceEvent struct {
+	ID        string
+	CreatedAt time.Time
+	Message   string
+}
+
+type Deployment struct {
+	ID                 string
+	Status             string
+	TaskDefinition     string
+	RolloutState       string
+	RolloutStateReason string
+	Desired            int32
+	Running            int32
+	Pending            int32
+	Failed             int32
+	CreatedAt          time.Time
+}
+
+type TaskDefinitionRevision struct {
+	Arn          string
+	Revision     int32
+	RegisteredAt time.Time
+	Images       []string
+}
+
+type TaskStatus struct {
+	Arn               string
+	ID                string
+	LastStatus        string
+	DesiredStatus     string
+	HealthStatus      string
+	AvailabilityZone  string
+	PrivateIP         string
+	TaskDefinitionArn string
+	LaunchType        string
+	Cpu               string
+	Memory            string
+	CreatedAt         time.Time
+	StartedAt         time.Time
+	StoppedAt         time.Time
+	StoppedReason     string
+	StopCode          string
+	Group             string
+	ExecuteCommand    bool
+	ContainerInstance string
+	Containers        []ContainerStatus
+	Attachments       []TaskAttachment
+}
+
+type ContainerStatus struct {
+	Name         string
+	LastStatus   string
+	HealthStatus string
+	ExitCode     *int32
+	Reason       string
+	Image        string
+	ImageDigest  string
+	RuntimeID    string
+	ExecAgent    string
+}
+
+type TaskAttachment struct {
+	ID      string
+	Type    string
+	Status  string
+	Details map[string]string
+}
+
+type TaskOverrides struct {
+	Container   string
+	Command     []string
+	Environment map[string]string
+}
+
+type ContainerLogConfig struct {
+	Group        string
+	Region       string
+	StreamPrefix string
+}
+
+type ExecConfig struct {
+	KmsKeyID             string
+	Logging              string
+	LogGroup             string
+	CloudWatchEncryption bool
+	S3Bucket             string
+	S3KeyPrefix          string
+	S3Encryption         bool
+}
+
+type ContainerInstance struct {
+	Arn              string
+	EC2InstanceID    string
+	Status           string
+	AgentVersion     string
+	AgentConnected   bool
+	RegisteredCPU    int32
+	RegisteredMemory int32
+	RemainingCPU     int32
+	RemainingMemory  int32
+	RunningTasks     int32
+	PendingTasks     int32
+}
+
+type ServiceTaskDefinition struct {
+	Cluster        string
+	Service        string
+	TaskDefinition *ecstypes.TaskDefinition
+}
+
+type ClusterSummary struct {
+	Arn          string
+	Name         string
+	Services     int32
+	RunningTasks int32
+}
+
+type ServiceStatus struct {
+	Arn                    string
+	Name                   string
+	Desired                int32
+	Running                int32
+	Pending                int32
+	TaskDefinition         string
+	LaunchType             string
+	Events                 []ServiceEvent
+	Deployments            []Deployment
+	CircuitBreakerEnabled  bool
+	CircuitBreakerRollback bool
+	ExecuteCommand         bool
+}
+
 func newECSClient(cfg aws.Config) *ecs.Client {
 	return ecs.NewFromConfig(cfg)
 }
@@ -58,6 +192,94 @@ func ListClusters(region string) (clusters []string, err error) {
 	return clusters, nil
 }
 
+// FindServiceTaskDefinitions looks for an active service with the given name in every cluster of the region
+// and returns the task definition each one is using. A non-empty profile is used instead of the default
+// credentials so that services in another account can be found.
+func FindServiceTaskDefinitions(region, profile, serviceName string) ([]ServiceTaskDefinition, error) {
+	cfg, err := shared.LoadAWSConfigWithProfile(region, profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+
+	var clusters []string
+
+	// Use a paginator to ensure we see all the results
+	paginator := ecs.NewListClustersPaginator(client, &ecs.ListClustersInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to list ECS clusters: %v", err)
+		}
+		clusters = append(clusters, page.ClusterArns...)
+	}
+
+	var found []ServiceTaskDefinition
+	for _, cluster := range clusters {
+		// Services that do not exist in the cluster are reported as failures rather than an error
+		output, err := client.DescribeServices(context.Background(), &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: []string{serviceName},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe services: %v", err)
+		}
+
+		for _, service := range output.Services {
+			if aws.ToString(service.Status) != "ACTIVE" {
+				continue
+			}
+			td, err := client.DescribeTaskDefinition(context.Background(), &ecs.DescribeTaskDefinitionInput{
+				TaskDefinition: service.TaskDefinition,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("unable to describe task definition: %v", err)
+			}
+			found = append(found, ServiceTaskDefinition{
+				Cluster:        cluster,
+				Service:        aws.ToString(service.ServiceArn),
+				TaskDefinition: td.TaskDefinition,
+			})
+		}
+	}
+
+	return found, nil
+}
+
+// DescribeClusterSummaries returns the name and counts of each cluster, describing them in batches of 100
+func DescribeClusterSummaries(region string, clusters []string) ([]ClusterSummary, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+
+	var summaries []ClusterSummary
+	for start := 0; start < len(clusters); start += 100 {
+		end := min(start+100, len(clusters))
+		input := &ecs.DescribeClustersInput{
+			Clusters: clusters[start:end],
+		}
+
+		output, err := client.DescribeClusters(context.Background(), input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe clusters: %v", err)
+		}
+		for _, cluster := range output.Clusters {
+			summaries = append(summaries, ClusterSummary{
+				Arn:          aws.ToString(cluster.ClusterArn),
+				Name:         aws.ToString(cluster.ClusterName),
+				Services:     cluster.ActiveServicesCount,
+				RunningTasks: cluster.RunningTasksCount,
+			})
+		}
+	}
+
+	return summaries, nil
+}
+
 func DescribeCluster(region, cluster string) ([]describeCluster, error) {
 	cfg, err := shared.LoadAWSConfig(region)
 	if err != nil {
@@ -263,26 +485,1000 @@ func DescribeTasks(region, cluster, task string) ([]taskInfo, error) {
 	return availableContainers, nil
 }
 
-func DescribeTaskDefinition(region, taskDefinition string) (string, error) {
+// GetServiceStatus returns the current counts and events of a service without querying CloudWatch, so it is cheap enough to poll
+func GetServiceStatus(region, cluster, service string) (ServiceStatus, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return ServiceStatus{}, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: []string{service},
+	}
+
+	output, err := client.DescribeServices(context.Background(), input)
+	if err != nil {
+		return ServiceStatus{}, fmt.Errorf("unable to describe service: %v", err)
+	}
+
+	if len(output.Services) == 0 {
+		return ServiceStatus{}, fmt.Errorf("service %s not found", service)
+	}
+
+	return newServiceStatus(output.Services[0]), nil
+}
+
+// GetServiceStatuses returns the status of several services, describing them in batches of ten as DescribeServices requires
+func GetServiceStatuses(region, cluster string, services []string) ([]ServiceStatus, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+
+	var statuses []ServiceStatus
+	for start := 0; start < len(services); start += 10 {
+		end := min(start+10, len(services))
+		input := &ecs.DescribeServicesInput{
+			Cluster:  aws.String(cluster),
+			Services: services[start:end],
+		}
+
+		output, err := client.DescribeServices(context.Background(), input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe services: %v", err)
+		}
+		for _, s := range output.Services {
+			statuses = append(statuses, newServiceStatus(s))
+		}
+	}
+
+	return statuses, nil
+}
+
+// ForceNewDeployment starts a new deployment of the service's current task definition and returns its ID
+func ForceNewDeployment(region, cluster, service string) (string, error) {
 	cfg, err := shared.LoadAWSConfig(region)
 	if err != nil {
 		return "", fmt.Errorf("unable to load AWS configuration: %v", err)
 	}
 
+	client := newECSClient(cfg)
+	input := &ecs.UpdateServiceInput{
+		Cluster:            aws.String(cluster),
+		Service:            aws.String(service),
+		ForceNewDeployment: true,
+	}
+
+	output, err := client.UpdateService(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("unable to update service: %v", err)
+	}
+
+	return primaryDeploymentID(output.Service), nil
+}
+
+// UpdateServiceExecuteCommand turns ECS Exec on or off for a service, optionally forcing a new deployment
+// so that running tasks are replaced with ones that pick up the change. The deployment ID is only returned
+// when a new deployment is forced.
+func UpdateServiceExecuteCommand(region, cluster, service string, enable, forceNewDeployment bool) (string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return "", fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.UpdateServiceInput{
+		Cluster:              aws.String(cluster),
+		Service:              aws.String(service),
+		EnableExecuteCommand: aws.Bool(enable),
+		ForceNewDeployment:   forceNewDeployment,
+	}
+
+	output, err := client.UpdateService(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("unable to update service: %v", err)
+	}
+
+	if !forceNewDeployment {
+		return "", nil
+	}
+	return primaryDeploymentID(output.Service), nil
+}
+
+func UpdateServiceDesiredCount(region, cluster, service string, desired int32) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.UpdateServiceInput{
+		Cluster:      aws.String(cluster),
+		Service:      aws.String(service),
+		DesiredCount: aws.Int32(desired),
+	}
+
+	_, err = client.UpdateService(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to update service: %v", err)
+	}
+	return nil
+}
+
+func newServiceStatus(s ecstypes.Service) ServiceStatus {
+	status := ServiceStatus{
+		Arn:            aws.ToString(s.ServiceArn),
+		Name:           aws.ToString(s.ServiceName),
+		Desired:        s.DesiredCount,
+		Running:        s.RunningCount,
+		Pending:        s.PendingCount,
+		TaskDefinition: aws.ToString(s.TaskDefinition),
+		LaunchType:     string(s.LaunchType),
+		Events:         newServiceEvents(s.Events),
+		ExecuteCommand: s.EnableExecuteCommand,
+	}
+
+	// Services using a capacity provider strategy do not report a launch type
+	if status.LaunchType == "" && len(s.CapacityProviderStrategy) > 0 {
+		status.LaunchType = aws.ToString(s.CapacityProviderStrategy[0].CapacityProvider)
+	}
+
+	if s.DeploymentConfiguration != nil && s.DeploymentConfiguration.DeploymentCircuitBreaker != nil {
+		status.CircuitBreakerEnabled = s.DeploymentConfiguration.DeploymentCircuitBreaker.Enable
+		status.CircuitBreakerRollback = s.DeploymentConfiguration.DeploymentCircuitBreaker.Rollback
+	}
+
+	for _, d := range s.Deployments {
+		status.Deployments = append(status.Deployments, Deployment{
+			ID:                 aws.ToString(d.Id),
+			Status:             aws.ToString(d.Status),
+			TaskDefinition:     aws.ToString(d.TaskDefinition),
+			RolloutState:       string(d.RolloutState),
+			RolloutStateReason: aws.ToString(d.RolloutStateReason),
+			Desired:            d.DesiredCount,
+			Running:            d.RunningCount,
+			Pending:            d.PendingCount,
+			Failed:             d.FailedTasks,
+			CreatedAt:          aws.ToTime(d.CreatedAt),
+		})
+	}
+	return status
+}
+
+// primaryDeploymentID returns the ID of the deployment ECS is currently rolling out
+func primaryDeploymentID(s *ecstypes.Service) string {
+	if s == nil {
+		return ""
+	}
+	for _, d := range s.Deployments {
+		if aws.ToString(d.Status) == "PRIMARY" {
+			return aws.ToString(d.Id)
+		}
+	}
+	return ""
+}
+
+// newServiceEvents converts the service events, which ECS returns newest first, into oldest first order
+func newServiceEvents(events []ecstypes.ServiceEvent) []ServiceEvent {
+	result := make([]ServiceEvent, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		result = append(result, ServiceEvent{
+			ID:        aws.ToString(events[i].Id),
+			CreatedAt: aws.ToTime(events[i].CreatedAt),
+			Message:   aws.ToString(events[i].Message),
+		})
+	}
+	return result
+}
+
+// GetTaskDefinition returns a task definition along with its tags
+func GetTaskDefinition(region, taskDefinition string) (*ecstypes.TaskDefinition, []ecstypes.Tag, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
 	client := newECSClient(cfg)
 	input := &ecs.DescribeTaskDefinitionInput{
-		TaskDefinition: &taskDefinition,
+		TaskDefinition: aws.String(taskDefinition),
+		Include:        []ecstypes.TaskDefinitionField{ecstypes.TaskDefinitionFieldTags},
 	}
 
 	output, err := client.DescribeTaskDefinition(context.Background(), input)
 	if err != nil {
-		return "", fmt.Errorf("unable to describe task definition: %v", err)
+		return nil, nil, fmt.Errorf("unable to describe task definition: %v", err)
+	}
+
+	return output.TaskDefinition, output.Tags, nil
+}
+
+// NewRegisterInput copies the registerable fields of a task definition, leaving out read-only
+// fields such as the revision, status and registration details and any tags reserved by AWS
+func NewRegisterInput(td *ecstypes.TaskDefinition, tags []ecstypes.Tag) *ecs.RegisterTaskDefinitionInput {
+	input := &ecs.RegisterTaskDefinitionInput{
+		ContainerDefinitions:    td.ContainerDefinitions,
+		Family:                  td.Family,
+		Cpu:                     td.Cpu,
+		EnableFaultInjection:    td.EnableFaultInjection,
+		EphemeralStorage:        td.EphemeralStorage,
+		ExecutionRoleArn:        td.ExecutionRoleArn,
+		InferenceAccelerators:   td.InferenceAccelerators,
+		IpcMode:                 td.IpcMode,
+		Memory:                  td.Memory,
+		NetworkMode:             td.NetworkMode,
+		PidMode:                 td.PidMode,
+		PlacementConstraints:    td.PlacementConstraints,
+		ProxyConfiguration:      td.ProxyConfiguration,
+		RequiresCompatibilities: td.RequiresCompatibilities,
+		RuntimePlatform:         td.RuntimePlatform,
+		TaskRoleArn:             td.TaskRoleArn,
+		Volumes:                 td.Volumes,
+	}
+	if tags := userTags(tags); len(tags) > 0 {
+		input.Tags = tags
+	}
+	return input
+}
+
+// userTags drops the aws: prefixed tags, such as aws:cloudformation:stack-name, which AWS adds
+// itself and which cannot be passed when registering a task definition
+func userTags(tags []ecstypes.Tag) []ecstypes.Tag {
+	var filtered []ecstypes.Tag
+	for _, tag := range tags {
+		if isReservedTagKey(aws.ToString(tag.Key)) {
+			continue
+		}
+		filtered = append(filtered, tag)
+	}
+	return filtered
+}
+
+func isReservedTagKey(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), "aws:")
+}
+
+// SetContainerImages replaces the image of each named container and returns the images it replaced
+func SetContainerImages(input *ecs.RegisterTaskDefinitionInput, images map[string]string) (map[string]string, error) {
+	previous := make(map[string]string)
+	for container, image := range images {
+		found := false
+		for i := range input.ContainerDefinitions {
+			if aws.ToString(input.ContainerDefinitions[i].Name) == container {
+				previous[container] = aws.ToString(input.ContainerDefinitions[i].Image)
+				input.ContainerDefinitions[i].Image = aws.String(image)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("container %s not found in task definition %s", container, aws.ToString(input.Family))
+		}
+	}
+	return previous, nil
+}
+
+// RegisterTaskDefinition registers a new task definition revision and returns its ARN
+func RegisterTaskDefinition(region string, input *ecs.RegisterTaskDefinitionInput) (string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return "", fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	output, err := client.RegisterTaskDefinition(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("unable to register task definition: %v", err)
+	}
+
+	return aws.ToString(output.TaskDefinition.TaskDefinitionArn), nil
+}
+
+// UpdateServiceTaskDefinition points a service at a task definition and returns the ID of the resulting deployment
+func UpdateServiceTaskDefinition(region, cluster, service, taskDefinition string) (string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return "", fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.UpdateServiceInput{
+		Cluster:        aws.String(cluster),
+		Service:        aws.String(service),
+		TaskDefinition: aws.String(taskDefinition),
+	}
+
+	output, err := client.UpdateService(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("unable to update service: %v", err)
 	}
 
-	outputJSON, err := json.MarshalIndent(output, "", " ")
+	return primaryDeploymentID(output.Service), nil
+}
+
+// ListTaskDefinitionRevisions returns up to limit of the most recent active revisions of a task definition family, newest first
+func ListTaskDefinitionRevisions(region, family string, limit int) ([]TaskDefinitionRevision, error) {
+	cfg, err := shared.LoadAWSConfig(region)
 	if err != nil {
-		return "", fmt.Errorf("unable to marshal task definition output: %v", err)
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String(family),
+		Sort:         ecstypes.SortOrderDesc,
+		Status:       ecstypes.TaskDefinitionStatusActive,
+	}
+
+	// FamilyPrefix also matches longer family names so only keep ARNs from this exact family
+	var arns []string
+	paginator := ecs.NewListTaskDefinitionsPaginator(client, input)
+	for paginator.HasMorePages() && len(arns) < limit {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to list task definitions: %v", err)
+		}
+		for _, arn := range page.TaskDefinitionArns {
+			if taskDefinitionFamily(arn) == family && len(arns) < limit {
+				arns = append(arns, arn)
+			}
+		}
+	}
+
+	var revisions []TaskDefinitionRevision
+	for _, arn := range arns {
+		output, err := client.DescribeTaskDefinition(context.Background(), &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String(arn),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe task definition: %v", err)
+		}
+		td := output.TaskDefinition
+		revision := TaskDefinitionRevision{
+			Arn:          arn,
+			Revision:     td.Revision,
+			RegisteredAt: aws.ToTime(td.RegisteredAt),
+		}
+		for _, container := range td.ContainerDefinitions {
+			revision.Images = append(revision.Images, fmt.Sprintf("%s=%s", aws.ToString(container.Name), aws.ToString(container.Image)))
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// DiffTaskDefinitions describes what changes between two task definitions, one line per difference.
+// Containers are matched by name and their environment, secrets and ports are compared as sets
+// so that reordering does not show up as a change.
+func DiffTaskDefinitions(from, to *ecstypes.TaskDefinition) []string {
+	var diff []string
+
+	diff = append(diff, diffValues("cpu", aws.ToString(from.Cpu), aws.ToString(to.Cpu))...)
+	diff = append(diff, diffValues("memory", aws.ToString(from.Memory), aws.ToString(to.Memory))...)
+	diff = append(diff, diffValues("networkMode", string(from.NetworkMode), string(to.NetworkMode))...)
+	diff = append(diff, diffValues("taskRoleArn", aws.ToString(from.TaskRoleArn), aws.ToString(to.TaskRoleArn))...)
+	diff = append(diff, diffValues("executionRoleArn", aws.ToString(from.ExecutionRoleArn), aws.ToString(to.ExecutionRoleArn))...)
+
+	fromContainers := make(map[string]ecstypes.ContainerDefinition)
+	for _, c := range from.ContainerDefinitions {
+		fromContainers[aws.ToString(c.Name)] = c
+	}
+	for _, c := range to.ContainerDefinitions {
+		name := aws.ToString(c.Name)
+		old, ok := fromContainers[name]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("container %s: added (%s)", name, aws.ToString(c.Image)))
+			continue
+		}
+		delete(fromContainers, name)
+		diff = append(diff, diffContainers(name, old, c)...)
+	}
+	for _, c := range from.ContainerDefinitions {
+		if _, ok := fromContainers[aws.ToString(c.Name)]; ok {
+			diff = append(diff, fmt.Sprintf("container %s: removed", aws.ToString(c.Name)))
+		}
+	}
+
+	return diff
+}
+
+func diffContainers(name string, from, to ecstypes.ContainerDefinition) []string {
+	prefix := fmt.Sprintf("container %s ", name)
+	var diff []string
+
+	diff = append(diff, diffValues(prefix+"image", aws.ToString(from.Image), aws.ToString(to.Image))...)
+	diff = append(diff, diffValues(prefix+"cpu", fmt.Sprint(from.Cpu), fmt.Sprint(to.Cpu))...)
+	diff = append(diff, diffValues(prefix+"memory", formatOptionalInt32(from.Memory), formatOptionalInt32(to.Memory))...)
+	diff = append(diff, diffValues(prefix+"memoryReservation", formatOptionalInt32(from.MemoryReservation), formatOptionalInt32(to.MemoryReservation))...)
+	diff = append(diff, diffValues(prefix+"command", strings.Join(from.Command, " "), strings.Join(to.Command, " "))...)
+
+	fromEnv := make(map[string]string)
+	for _, kv := range from.Environment {
+		fromEnv[aws.ToString(kv.Name)] = aws.ToString(kv.Value)
+	}
+	toEnv := make(map[string]string)
+	for _, kv := range to.Environment {
+		toEnv[aws.ToString(kv.Name)] = aws.ToString(kv.Value)
+	}
+	diff = append(diff, diffMaps(prefix+"env", fromEnv, toEnv)...)
+
+	fromSecrets := make(map[string]string)
+	for _, secret := range from.Secrets {
+		fromSecrets[aws.ToString(secret.Name)] = aws.ToString(secret.ValueFrom)
+	}
+	toSecrets := make(map[string]string)
+	for _, secret := range to.Secrets {
+		toSecrets[aws.ToString(secret.Name)] = aws.ToString(secret.ValueFrom)
 	}
+	diff = append(diff, diffMaps(prefix+"secret", fromSecrets, toSecrets)...)
 
-	return string(outputJSON), nil
+	fromPorts := make(map[string]string)
+	for _, port := range from.PortMappings {
+		fromPorts[formatPortMapping(port)] = ""
+	}
+	toPorts := make(map[string]string)
+	for _, port := range to.PortMappings {
+		toPorts[formatPortMapping(port)] = ""
+	}
+	diff = append(diff, diffMaps(prefix+"port", fromPorts, toPorts)...)
+
+	return diff
+}
+
+func diffValues(name, from, to string) []string {
+	if from == to {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: %s -> %s", name, valueOrNone(from), valueOrNone(to))}
+}
+
+// diffMaps reports added, removed and changed keys in a stable order
+func diffMaps(name string, from, to map[string]string) []string {
+	keys := make(map[string]bool)
+	for key := range from {
+		keys[key] = true
+	}
+	for key := range to {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	var diff []string
+	for _, key := range sorted {
+		a, inFrom := from[key]
+		b, inTo := to[key]
+		switch {
+		case !inFrom && b == "":
+			diff = append(diff, fmt.Sprintf("%s %s: added", name, key))
+		case !inFrom:
+			diff = append(diff, fmt.Sprintf("%s %s: added %s", name, key, b))
+		case !inTo:
+			diff = append(diff, fmt.Sprintf("%s %s: removed", name, key))
+		case a != b:
+			diff = append(diff, fmt.Sprintf("%s %s: %s -> %s", name, key, valueOrNone(a), valueOrNone(b)))
+		}
+	}
+	return diff
+}
+
+func formatPortMapping(port ecstypes.PortMapping) string {
+	mapping := formatOptionalInt32(port.ContainerPort)
+	if port.ContainerPortRange != nil {
+		mapping = aws.ToString(port.ContainerPortRange)
+	}
+	if port.HostPort != nil && aws.ToInt32(port.HostPort) != aws.ToInt32(port.ContainerPort) {
+		mapping = fmt.Sprintf("%d:%s", aws.ToInt32(port.HostPort), mapping)
+	}
+	protocol := strings.ToLower(string(port.Protocol))
+	if protocol == "" {
+		protocol = "tcp"
+	}
+	return fmt.Sprintf("%s/%s", mapping, protocol)
+}
+
+func formatOptionalInt32(value *int32) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
+
+// taskDefinitionFamily returns the family from a task definition ARN or family:revision string
+func taskDefinitionFamily(taskDefinition string) string {
+	parts := strings.Split(taskDefinition, "/")
+	family, _, _ := strings.Cut(parts[len(parts)-1], ":")
+	return family
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// GetContainerLogConfig returns where a container's awslogs log driver sends its logs
+func GetContainerLogConfig(region string, td *ecstypes.TaskDefinition, container string) (ContainerLogConfig, error) {
+	for _, c := range td.ContainerDefinitions {
+		if aws.ToString(c.Name) != container {
+			continue
+		}
+		if c.LogConfiguration == nil || c.LogConfiguration.LogDriver != ecstypes.LogDriverAwslogs {
+			return ContainerLogConfig{}, fmt.Errorf("container %s does not use the awslogs log driver", container)
+		}
+		options := c.LogConfiguration.Options
+		logConfig := ContainerLogConfig{
+			Group:        options["awslogs-group"],
+			Region:       options["awslogs-region"],
+			StreamPrefix: options["awslogs-stream-prefix"],
+		}
+		if logConfig.Region == "" {
+			logConfig.Region = region
+		}
+		return logConfig, nil
+	}
+
+	return ContainerLogConfig{}, fmt.Errorf("container %s not found in task definition %s", container, aws.ToString(td.TaskDefinitionArn))
+}
+
+// LogStream returns the awslogs stream name of a container in a task, which is only known when a stream prefix is set
+func (c ContainerLogConfig) LogStream(container, taskArn string) (string, error) {
+	if c.StreamPrefix == "" {
+		return "", fmt.Errorf("log group %s has no awslogs-stream-prefix so task streams cannot be found", c.Group)
+	}
+	return fmt.Sprintf("%s/%s/%s", c.StreamPrefix, container, TaskID(taskArn)), nil
+}
+
+// TaskID returns the ID at the end of a task ARN
+func TaskID(taskArn string) string {
+	return taskArn[strings.LastIndex(taskArn, "/")+1:]
+}
+
+// ListStoppedTasks returns the recently stopped tasks of a service, or of the whole cluster when service is empty.
+// ECS only keeps stopped tasks for a short time, typically around an hour.
+func ListStoppedTasks(region, cluster, service string) ([]string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.ListTasksInput{
+		Cluster:       aws.String(cluster),
+		DesiredStatus: ecstypes.DesiredStatusStopped,
+	}
+	if service != "" {
+		input.ServiceName = aws.String(service)
+	}
+
+	// Use a paginator to ensure we see all the results
+	var tasks []string
+	paginator := ecs.NewListTasksPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to list stopped ECS tasks: %v", err)
+		}
+		tasks = append(tasks, page.TaskArns...)
+	}
+
+	return tasks, nil
+}
+
+// DescribeTaskStatuses returns the status of several tasks, describing them in batches of 100 as DescribeTasks requires
+func DescribeTaskStatuses(region, cluster string, tasks []string) ([]TaskStatus, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+
+	var statuses []TaskStatus
+	for start := 0; start < len(tasks); start += 100 {
+		end := min(start+100, len(tasks))
+		input := &ecs.DescribeTasksInput{
+			Cluster: aws.String(cluster),
+			Tasks:   tasks[start:end],
+		}
+
+		output, err := client.DescribeTasks(context.Background(), input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe tasks: %v", err)
+		}
+		for _, task := range output.Tasks {
+			statuses = append(statuses, newTaskStatus(task))
+		}
+	}
+
+	return statuses, nil
+}
+
+func StopTask(region, cluster, task, reason string) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.StopTaskInput{
+		Cluster: aws.String(cluster),
+		Task:    aws.String(task),
+	}
+	if reason != "" {
+		input.Reason = aws.String(reason)
+	}
+
+	_, err = client.StopTask(context.Background(), input)
+	if err != nil {
+		return fmt.Errorf("unable to stop task: %v", err)
+	}
+	return nil
+}
+
+// RunServiceTask starts a one-off task from a service's task definition, using the same network
+// configuration, launch type or capacity provider strategy and platform version as the service
+func RunServiceTask(region, cluster, service string, overrides TaskOverrides) (string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return "", fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	output, err := client.DescribeServices(context.Background(), &ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: []string{service},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to describe service: %v", err)
+	}
+
+	if len(output.Services) == 0 {
+		return "", fmt.Errorf("service %s not found", service)
+	}
+
+	s := output.Services[0]
+	input := &ecs.RunTaskInput{
+		Cluster:                  aws.String(cluster),
+		TaskDefinition:           s.TaskDefinition,
+		NetworkConfiguration:     s.NetworkConfiguration,
+		PlatformVersion:          s.PlatformVersion,
+		EnableExecuteCommand:     s.EnableExecuteCommand,
+		PlacementConstraints:     s.PlacementConstraints,
+		PlacementStrategy:        s.PlacementStrategy,
+		StartedBy:                aws.String("go-aws"),
+		Count:                    aws.Int32(1),
+		CapacityProviderStrategy: s.CapacityProviderStrategy,
+	}
+	// A launch type cannot be given alongside a capacity provider strategy
+	if len(s.CapacityProviderStrategy) == 0 {
+		input.LaunchType = s.LaunchType
+	}
+
+	if len(overrides.Command) > 0 || len(overrides.Environment) > 0 {
+		containerOverride := ecstypes.ContainerOverride{
+			Name:    aws.String(overrides.Container),
+			Command: overrides.Command,
+		}
+		for name, value := range overrides.Environment {
+			containerOverride.Environment = append(containerOverride.Environment, ecstypes.KeyValuePair{
+				Name:  aws.String(name),
+				Value: aws.String(value),
+			})
+		}
+		input.Overrides = &ecstypes.TaskOverride{
+			ContainerOverrides: []ecstypes.ContainerOverride{containerOverride},
+		}
+	}
+
+	runOutput, err := client.RunTask(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("unable to run task: %v", err)
+	}
+
+	if len(runOutput.Failures) > 0 {
+		failure := runOutput.Failures[0]
+		return "", fmt.Errorf("unable to run task: %s %s", aws.ToString(failure.Reason), aws.ToString(failure.Detail))
+	}
+
+	if len(runOutput.Tasks) == 0 {
+		return "", fmt.Errorf("unable to run task: no task was started")
+	}
+
+	return aws.ToString(runOutput.Tasks[0].TaskArn), nil
+}
+
+// GetClusterExecConfig returns the cluster's ECS Exec KMS and logging configuration
+func GetClusterExecConfig(region, cluster string) (ExecConfig, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return ExecConfig{}, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.DescribeClustersInput{
+		Clusters: []string{cluster},
+		Include:  []ecstypes.ClusterField{ecstypes.ClusterFieldConfigurations},
+	}
+
+	output, err := client.DescribeClusters(context.Background(), input)
+	if err != nil {
+		return ExecConfig{}, fmt.Errorf("unable to describe cluster: %v", err)
+	}
+
+	if len(output.Clusters) == 0 {
+		return ExecConfig{}, fmt.Errorf("cluster %s not found", cluster)
+	}
+
+	// Clusters without any exec configuration use the default logging
+	execConfig := ExecConfig{Logging: string(ecstypes.ExecuteCommandLoggingDefault)}
+	configuration := output.Clusters[0].Configuration
+	if configuration == nil || configuration.ExecuteCommandConfiguration == nil {
+		return execConfig, nil
+	}
+
+	c := configuration.ExecuteCommandConfiguration
+	execConfig.KmsKeyID = aws.ToString(c.KmsKeyId)
+	if c.Logging != "" {
+		execConfig.Logging = string(c.Logging)
+	}
+	if c.LogConfiguration != nil {
+		execConfig.LogGroup = aws.ToString(c.LogConfiguration.CloudWatchLogGroupName)
+		execConfig.CloudWatchEncryption = c.LogConfiguration.CloudWatchEncryptionEnabled
+		execConfig.S3Bucket = aws.ToString(c.LogConfiguration.S3BucketName)
+		execConfig.S3KeyPrefix = aws.ToString(c.LogConfiguration.S3KeyPrefix)
+		execConfig.S3Encryption = c.LogConfiguration.S3EncryptionEnabled
+	}
+	return execConfig, nil
+}
+
+// execAgentStatus returns the last status of a container's ECS Exec agent, or an empty string if it has none
+func execAgentStatus(agents []ecstypes.ManagedAgent) string {
+	for _, agent := range agents {
+		if agent.Name == ecstypes.ManagedAgentNameExecuteCommandAgent {
+			return aws.ToString(agent.LastStatus)
+		}
+	}
+	return ""
+}
+
+func newTaskStatus(task ecstypes.Task) TaskStatus {
+	status := TaskStatus{
+		Arn:               aws.ToString(task.TaskArn),
+		ID:                TaskID(aws.ToString(task.TaskArn)),
+		LastStatus:        aws.ToString(task.LastStatus),
+		DesiredStatus:     aws.ToString(task.DesiredStatus),
+		HealthStatus:      string(task.HealthStatus),
+		AvailabilityZone:  aws.ToString(task.AvailabilityZone),
+		TaskDefinitionArn: aws.ToString(task.TaskDefinitionArn),
+		LaunchType:        string(task.LaunchType),
+		Cpu:               aws.ToString(task.Cpu),
+		Memory:            aws.ToString(task.Memory),
+		CreatedAt:         aws.ToTime(task.CreatedAt),
+		StartedAt:         aws.ToTime(task.StartedAt),
+		StoppedAt:         aws.ToTime(task.StoppedAt),
+		StoppedReason:     aws.ToString(task.StoppedReason),
+		StopCode:          string(task.StopCode),
+		Group:             aws.ToString(task.Group),
+		ExecuteCommand:    task.EnableExecuteCommand,
+		ContainerInstance: aws.ToString(task.ContainerInstanceArn),
+	}
+
+	// Capacity provider tasks do not report a launch type
+	if status.LaunchType == "" {
+		status.LaunchType = aws.ToString(task.CapacityProviderName)
+	}
+
+	for _, attachment := range task.Attachments {
+		details := make(map[string]string)
+		for _, detail := range attachment.Details {
+			details[aws.ToString(detail.Name)] = aws.ToString(detail.Value)
+		}
+		status.Attachments = append(status.Attachments, TaskAttachment{
+			ID:      aws.ToString(attachment.Id),
+			Type:    aws.ToString(attachment.Type),
+			Status:  aws.ToString(attachment.Status),
+			Details: details,
+		})
+		// awsvpc tasks get their address from the task's network interface
+		if ip, ok := details["privateIPv4Address"]; ok && status.PrivateIP == "" {
+			status.PrivateIP = ip
+		}
+	}
+
+	for _, container := range task.Containers {
+		status.Containers = append(status.Containers, ContainerStatus{
+			Name:         aws.ToString(container.Name),
+			LastStatus:   aws.ToString(container.LastStatus),
+			HealthStatus: string(container.HealthStatus),
+			ExitCode:     container.ExitCode,
+			Reason:       aws.ToString(container.Reason),
+			Image:        aws.ToString(container.Image),
+			ImageDigest:  aws.ToString(container.ImageDigest),
+			RuntimeID:    aws.ToString(container.RuntimeId),
+			ExecAgent:    execAgentStatus(container.ManagedAgents),
+		})
+		for _, networkInterface := range container.NetworkInterfaces {
+			if status.PrivateIP == "" {
+				status.PrivateIP = aws.ToString(networkInterface.PrivateIpv4Address)
+			}
+		}
+	}
+	return status
+}
+
+// GetServiceUtilization returns the average CPU and memory utilization of a service over the last 5 minutes
+func GetServiceUtilization(region, cluster, service string) (cpu, memory float64, err error) {
+	// The metric dimensions use the names rather than the ARNs
+	clusterName := cluster[strings.LastIndex(cluster, "/")+1:]
+	serviceName := service[strings.LastIndex(service, "/")+1:]
+
+	// Time vars for cloudwatch queries
+	startTime := time.Now().Add(-5 * time.Minute)
+	endTime := time.Now()
+
+	dimensions := []types.Dimension{
+		{Name: aws.String("ClusterName"), Value: aws.String(clusterName)},
+		{Name: aws.String("ServiceName"), Value: aws.String(serviceName)},
+	}
+
+	metric := &MetricStats{
+		Namespace:  "AWS/ECS",
+		MetricName: "CPUUtilization",
+		Dimensions: dimensions,
+		StartTime:  &startTime,
+		EndTime:    &endTime,
+		Period:     300,
+		Statistics: []types.Statistic{types.StatisticAverage},
+	}
+	cpu, err = GetMetricStats(region, metric)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	metric.MetricName = "MemoryUtilization"
+	memory, err = GetMetricStats(region, metric)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return cpu, memory, nil
+}
+
+// ListContainerInstances returns the EC2 container instances registered to a cluster
+func ListContainerInstances(region, cluster string) ([]ContainerInstance, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.ListContainerInstancesInput{
+		Cluster: aws.String(cluster),
+	}
+
+	var arns []string
+
+	// Use a paginator to ensure we see all the results
+	paginator := ecs.NewListContainerInstancesPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to list container instances: %v", err)
+		}
+		arns = append(arns, page.ContainerInstanceArns...)
+	}
+
+	var instances []ContainerInstance
+	for start := 0; start < len(arns); start += 100 {
+		end := min(start+100, len(arns))
+		input := &ecs.DescribeContainerInstancesInput{
+			Cluster:            aws.String(cluster),
+			ContainerInstances: arns[start:end],
+		}
+
+		output, err := client.DescribeContainerInstances(context.Background(), input)
+		if err != nil {
+			return nil, fmt.Errorf("unable to describe container instances: %v", err)
+		}
+		for _, instance := range output.ContainerInstances {
+			instances = append(instances, newContainerInstance(instance))
+		}
+	}
+
+	return instances, nil
+}
+
+// UpdateContainerInstancesState sets container instances to ACTIVE or DRAINING. Draining instances
+// have their service tasks replaced elsewhere and receive no new tasks.
+func UpdateContainerInstancesState(region, cluster string, instances []string, status string) error {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+
+	// The API accepts at most 10 container instances per call
+	for start := 0; start < len(instances); start += 10 {
+		end := min(start+10, len(instances))
+		input := &ecs.UpdateContainerInstancesStateInput{
+			Cluster:            aws.String(cluster),
+			ContainerInstances: instances[start:end],
+			Status:             ecstypes.ContainerInstanceStatus(status),
+		}
+
+		output, err := client.UpdateContainerInstancesState(context.Background(), input)
+		if err != nil {
+			return fmt.Errorf("unable to update container instances: %v", err)
+		}
+		if len(output.Failures) > 0 {
+			failure := output.Failures[0]
+			return fmt.Errorf("unable to update container instance %s: %s", aws.ToString(failure.Arn), aws.ToString(failure.Reason))
+		}
+	}
+	return nil
+}
+
+// GetContainerInstanceEC2ID returns the ID of the EC2 instance behind a container instance
+func GetContainerInstanceEC2ID(region, cluster, containerInstance string) (string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return "", fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := newECSClient(cfg)
+	input := &ecs.DescribeContainerInstancesInput{
+		Cluster:            aws.String(cluster),
+		ContainerInstances: []string{containerInstance},
+	}
+
+	output, err := client.DescribeContainerInstances(context.Background(), input)
+	if err != nil {
+		return "", fmt.Errorf("unable to describe container instance: %v", err)
+	}
+
+	if len(output.ContainerInstances) == 0 {
+		return "", fmt.Errorf("container instance %s not found", containerInstance)
+	}
+	return aws.ToString(output.ContainerInstances[0].Ec2InstanceId), nil
+}
+
+func newContainerInstance(instance ecstypes.ContainerInstance) ContainerInstance {
+	ci := ContainerInstance{
+		Arn:            aws.ToString(instance.ContainerInstanceArn),
+		EC2InstanceID:  aws.ToString(instance.Ec2InstanceId),
+		Status:         aws.ToString(instance.Status),
+		AgentConnected: instance.AgentConnected,
+		RunningTasks:   instance.RunningTasksCount,
+		PendingTasks:   instance.PendingTasksCount,
+	}
+	if instance.VersionInfo != nil {
+		ci.AgentVersion = aws.ToString(instance.VersionInfo.AgentVersion)
+	}
+	ci.RegisteredCPU, ci.RegisteredMemory = cpuAndMemory(instance.RegisteredResources)
+	ci.RemainingCPU, ci.RemainingMemory = cpuAndMemory(instance.RemainingResources)
+	return ci
+}
+
+// cpuAndMemory picks the CPU units and MiB of memory out of a container instance's resources
+func cpuAndMemory(resources []ecstypes.Resource) (int32, int32) {
+	var cpu, memory int32
+	for _, resource := range resources {
+		switch aws.ToString(resource.Name) {
+		case "CPU":
+			cpu = resource.IntegerValue
+		case "MEMORY":
+			memory = resource.IntegerValue
+		}
+	}
+	return cpu, memory
 }
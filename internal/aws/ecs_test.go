@@ -0,0 +1,217 @@
+package aws
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+func TestDiffTaskDefinitions(t *testing.T) {
+	container := func(name, image string) ecstypes.ContainerDefinition {
+		return ecstypes.ContainerDefinition{Name: aws.String(name), Image: aws.String(image)}
+	}
+	env := func(pairs ...string) []ecstypes.KeyValuePair {
+		var env []ecstypes.KeyValuePair
+		for i := 0; i < len(pairs); i += 2 {
+			env = append(env, ecstypes.KeyValuePair{Name: aws.String(pairs[i]), Value: aws.String(pairs[i+1])})
+		}
+		return env
+	}
+	secrets := func(pairs ...string) []ecstypes.Secret {
+		var secrets []ecstypes.Secret
+		for i := 0; i < len(pairs); i += 2 {
+			secrets = append(secrets, ecstypes.Secret{Name: aws.String(pairs[i]), ValueFrom: aws.String(pairs[i+1])})
+		}
+		return secrets
+	}
+	withContainers := func(containers ...ecstypes.ContainerDefinition) *ecstypes.TaskDefinition {
+		return &ecstypes.TaskDefinition{ContainerDefinitions: containers}
+	}
+
+	tests := []struct {
+		name string
+		from *ecstypes.TaskDefinition
+		to   *ecstypes.TaskDefinition
+		want []string
+	}{
+		{
+			name: "identical",
+			from: withContainers(container("app", "nginx:1")),
+			to:   withContainers(container("app", "nginx:1")),
+			want: nil,
+		},
+		{
+			name: "container added and removed",
+			from: withContainers(container("app", "nginx:1"), container("sidecar", "envoy:1")),
+			to:   withContainers(container("app", "nginx:1"), container("worker", "worker:2")),
+			want: []string{
+				"container worker: added (worker:2)",
+				"container sidecar: removed",
+			},
+		},
+		{
+			name: "image changed",
+			from: withContainers(container("app", "nginx:1")),
+			to:   withContainers(container("app", "nginx:2")),
+			want: []string{"container app image: nginx:1 -> nginx:2"},
+		},
+		{
+			name: "env and secrets reordered",
+			from: withContainers(ecstypes.ContainerDefinition{
+				Name:        aws.String("app"),
+				Environment: env("A", "1", "B", "2"),
+				Secrets:     secrets("DB", "arn:db", "API", "arn:api"),
+			}),
+			to: withContainers(ecstypes.ContainerDefinition{
+				Name:        aws.String("app"),
+				Environment: env("B", "2", "A", "1"),
+				Secrets:     secrets("API", "arn:api", "DB", "arn:db"),
+			}),
+			want: nil,
+		},
+		{
+			name: "env added, removed and changed",
+			from: withContainers(ecstypes.ContainerDefinition{
+				Name:        aws.String("app"),
+				Environment: env("KEEP", "1", "OLD", "x", "LEVEL", "info"),
+			}),
+			to: withContainers(ecstypes.ContainerDefinition{
+				Name:        aws.String("app"),
+				Environment: env("KEEP", "1", "NEW", "y", "LEVEL", "debug"),
+			}),
+			want: []string{
+				"container app env LEVEL: info -> debug",
+				"container app env NEW: added y",
+				"container app env OLD: removed",
+			},
+		},
+		{
+			name: "secret changed",
+			from: withContainers(ecstypes.ContainerDefinition{Name: aws.String("app"), Secrets: secrets("DB", "arn:db:1")}),
+			to:   withContainers(ecstypes.ContainerDefinition{Name: aws.String("app"), Secrets: secrets("DB", "arn:db:2")}),
+			want: []string{"container app secret DB: arn:db:1 -> arn:db:2"},
+		},
+		{
+			name: "host port differs from container port",
+			from: withContainers(ecstypes.ContainerDefinition{
+				Name:         aws.String("app"),
+				PortMappings: []ecstypes.PortMapping{{ContainerPort: aws.Int32(80), HostPort: aws.Int32(80), Protocol: ecstypes.TransportProtocolTcp}},
+			}),
+			to: withContainers(ecstypes.ContainerDefinition{
+				Name:         aws.String("app"),
+				PortMappings: []ecstypes.PortMapping{{ContainerPort: aws.Int32(80), HostPort: aws.Int32(8080), Protocol: ecstypes.TransportProtocolTcp}},
+			}),
+			want: []string{
+				"container app port 80/tcp: removed",
+				"container app port 8080:80/tcp: added",
+			},
+		},
+		{
+			name: "port without a protocol defaults to tcp",
+			from: withContainers(ecstypes.ContainerDefinition{
+				Name:         aws.String("app"),
+				PortMappings: []ecstypes.PortMapping{{ContainerPort: aws.Int32(80), Protocol: ecstypes.TransportProtocolTcp}},
+			}),
+			to: withContainers(ecstypes.ContainerDefinition{
+				Name:         aws.String("app"),
+				PortMappings: []ecstypes.PortMapping{{ContainerPort: aws.Int32(80)}, {ContainerPort: aws.Int32(53), Protocol: ecstypes.TransportProtocolUdp}},
+			}),
+			want: []string{"container app port 53/udp: added"},
+		},
+		{
+			name: "task and execution roles changed",
+			from: &ecstypes.TaskDefinition{
+				TaskRoleArn:      aws.String("arn:aws:iam::1:role/old-task"),
+				ExecutionRoleArn: aws.String("arn:aws:iam::1:role/old-exec"),
+			},
+			to: &ecstypes.TaskDefinition{
+				TaskRoleArn: aws.String("arn:aws:iam::1:role/new-task"),
+			},
+			want: []string{
+				"taskRoleArn: arn:aws:iam::1:role/old-task -> arn:aws:iam::1:role/new-task",
+				"executionRoleArn: arn:aws:iam::1:role/old-exec -> (none)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffTaskDefinitions(tt.from, tt.to)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("DiffTaskDefinitions() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetContainerImages(t *testing.T) {
+	newInput := func() *ecs.RegisterTaskDefinitionInput {
+		return &ecs.RegisterTaskDefinitionInput{
+			Family: aws.String("web"),
+			ContainerDefinitions: []ecstypes.ContainerDefinition{
+				{Name: aws.String("app"), Image: aws.String("app:1")},
+				{Name: aws.String("proxy"), Image: aws.String("nginx:1")},
+				{Name: aws.String("agent"), Image: aws.String("agent:1")},
+			},
+		}
+	}
+	images := func(input *ecs.RegisterTaskDefinitionInput) []string {
+		var images []string
+		for _, c := range input.ContainerDefinitions {
+			images = append(images, aws.ToString(c.Image))
+		}
+		return images
+	}
+
+	tests := []struct {
+		name         string
+		images       map[string]string
+		wantImages   []string
+		wantPrevious map[string]string
+		wantErr      bool
+	}{
+		{
+			name:         "one container",
+			images:       map[string]string{"app": "app:2"},
+			wantImages:   []string{"app:2", "nginx:1", "agent:1"},
+			wantPrevious: map[string]string{"app": "app:1"},
+		},
+		{
+			name:         "several containers",
+			images:       map[string]string{"app": "app:2", "agent": "agent:3"},
+			wantImages:   []string{"app:2", "nginx:1", "agent:3"},
+			wantPrevious: map[string]string{"app": "app:1", "agent": "agent:1"},
+		},
+		{
+			name:    "unknown container",
+			images:  map[string]string{"app": "app:2", "worker": "worker:1"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := newInput()
+			previous, err := SetContainerImages(input, tt.images)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SetContainerImages() succeeded, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetContainerImages() returned error: %v", err)
+			}
+			if got := images(input); !slices.Equal(got, tt.wantImages) {
+				t.Errorf("images = %q, want %q", got, tt.wantImages)
+			}
+			if !maps.Equal(previous, tt.wantPrevious) {
+				t.Errorf("previous = %v, want %v", previous, tt.wantPrevious)
+			}
+		})
+	}
+}
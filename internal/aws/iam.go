@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CharonWare/go-aws/internal/shared"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+)
+
+// SimulateRoleActions uses the IAM policy simulator to check whether a role is allowed each action.
+// An empty resources list simulates against every resource, otherwise an action is only reported
+// as allowed if it is allowed on all of the given resources.
+func SimulateRoleActions(region, roleArn string, actions, resources []string) (map[string]bool, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := iam.NewFromConfig(cfg)
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(roleArn),
+		ActionNames:     actions,
+		ResourceArns:    resources,
+	}
+
+	allowed := make(map[string]bool)
+
+	// Use a paginator to ensure we see all the results
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("unable to simulate policy for %s: %v", roleArn, err)
+		}
+		for _, result := range page.EvaluationResults {
+			action := aws.ToString(result.EvalActionName)
+			decision := result.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed
+			if previous, ok := allowed[action]; ok {
+				decision = decision && previous
+			}
+			allowed[action] = decision
+		}
+	}
+
+	return allowed, nil
+}
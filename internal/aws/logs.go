@@ -0,0 +1,97 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/CharonWare/go-aws/internal/shared"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+type LogEvent struct {
+	ID        string
+	Timestamp time.Time
+	Stream    string
+	Message   string
+}
+
+// FilterLogEvents returns the events in the given streams of a log group from since onwards, oldest first.
+// An empty filter matches every event, otherwise it is a CloudWatch Logs filter pattern.
+func FilterLogEvents(region, group string, streams []string, since time.Time, filter string) ([]LogEvent, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := cloudwatchlogs.NewFromConfig(cfg)
+
+	var events []LogEvent
+
+	// FilterLogEvents accepts at most 100 stream names per request
+	for start := 0; start < len(streams); start += 100 {
+		end := min(start+100, len(streams))
+		input := &cloudwatchlogs.FilterLogEventsInput{
+			LogGroupName:   aws.String(group),
+			LogStreamNames: streams[start:end],
+			StartTime:      aws.Int64(since.UnixMilli()),
+		}
+		if filter != "" {
+			input.FilterPattern = aws.String(filter)
+		}
+
+		// Use a paginator to ensure we see all the results
+		paginator := cloudwatchlogs.NewFilterLogEventsPaginator(client, input)
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("unable to filter log events: %v", err)
+			}
+			for _, event := range page.Events {
+				events = append(events, LogEvent{
+					ID:        aws.ToString(event.EventId),
+					Timestamp: time.UnixMilli(aws.ToInt64(event.Timestamp)),
+					Stream:    aws.ToString(event.LogStreamName),
+					Message:   aws.ToString(event.Message),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	return events, nil
+}
+
+// GetLogGroupKmsKey returns the ARN of the KMS key a log group is encrypted with, or an empty string if it is not encrypted
+func GetLogGroupKmsKey(region, group string) (string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return "", fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := cloudwatchlogs.NewFromConfig(cfg)
+	input := &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(group),
+	}
+
+	// Use a paginator to ensure we see all the results
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background())
+		if err != nil {
+			return "", fmt.Errorf("unable to describe log groups: %v", err)
+		}
+		for _, logGroup := range page.LogGroups {
+			if aws.ToString(logGroup.LogGroupName) == group {
+				return aws.ToString(logGroup.KmsKeyId), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("log group %s not found", group)
+}
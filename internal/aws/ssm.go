@@ -51,3 +51,23 @@ func ListEC2Instances(region string) ([]EC2Instance, error) {
 
 	return instances, nil
 }
+
+// ListRegions returns the regions enabled for the account
+func ListRegions(region string) ([]string, error) {
+	cfg, err := shared.LoadAWSConfig(region)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %v", err)
+	}
+
+	client := ec2.NewFromConfig(cfg)
+	output, err := client.DescribeRegions(context.Background(), &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to describe regions: %v", err)
+	}
+
+	var regions []string
+	for _, r := range output.Regions {
+		regions = append(regions, *r.RegionName)
+	}
+	return regions, nil
+}
@@ -0,0 +1,186 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"sigs.k8s.io/yaml"
+)
+
+// ExportTaskDefinition renders a task definition as a clean register input document in JSON or YAML,
+// without read-only fields such as the revision and status, so that it can be registered again with
+// ImportTaskDefinition or aws ecs register-task-definition --cli-input-json. register-input is kept as
+// another name for json.
+func ExportTaskDefinition(region, taskDefinition, format string) (string, error) {
+	switch format {
+	case "json", "register-input":
+		format = "json"
+	case "yaml":
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected json or yaml", format)
+	}
+
+	td, tags, err := GetTaskDefinition(region, taskDefinition)
+	if err != nil {
+		return "", err
+	}
+	output, err := MarshalRegisterInput(NewRegisterInput(td, tags), format)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// ImportTaskDefinition registers a new revision from a register input document in JSON or YAML and returns its ARN
+func ImportTaskDefinition(region string, document []byte, format string) (string, error) {
+	input, err := UnmarshalRegisterInput(document, format)
+	if err != nil {
+		return "", err
+	}
+	return RegisterTaskDefinition(region, input)
+}
+
+// MarshalRegisterInput renders a register input as a JSON or YAML document using the same camelCase
+// field names as the AWS CLI, so it can be edited by hand or passed to --cli-input-json
+func MarshalRegisterInput(input *ecs.RegisterTaskDefinitionInput, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(toDocument(reflect.ValueOf(input)), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal task definition: %v", err)
+	}
+
+	switch format {
+	case "json":
+		return append(data, '\n'), nil
+	case "yaml":
+		return yaml.JSONToYAML(data)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected json or yaml", format)
+	}
+}
+
+// UnmarshalRegisterInput parses a JSON or YAML register input document and checks that it can be registered
+func UnmarshalRegisterInput(data []byte, format string) (*ecs.RegisterTaskDefinitionInput, error) {
+	switch format {
+	case "json":
+	case "yaml":
+		var err error
+		data, err = yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML: %v", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected json or yaml", format)
+	}
+
+	// Field names match case-insensitively so both camelCase and the SDK's own names are accepted,
+	// but anything unknown (such as a read-only field) is rejected rather than silently dropped
+	var input ecs.RegisterTaskDefinitionInput
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&input); err != nil {
+		return nil, fmt.Errorf("invalid task definition: %v", err)
+	}
+
+	if aws.ToString(input.Family) == "" {
+		return nil, fmt.Errorf("invalid task definition: family is required")
+	}
+	if len(input.ContainerDefinitions) == 0 {
+		return nil, fmt.Errorf("invalid task definition: at least one container definition is required")
+	}
+	for i, container := range input.ContainerDefinitions {
+		if aws.ToString(container.Name) == "" {
+			return nil, fmt.Errorf("invalid task definition: container %d has no name", i)
+		}
+		if aws.ToString(container.Image) == "" {
+			return nil, fmt.Errorf("invalid task definition: container %s has no image", aws.ToString(container.Name))
+		}
+	}
+
+	for _, tag := range input.Tags {
+		if isReservedTagKey(aws.ToString(tag.Key)) {
+			return nil, fmt.Errorf("invalid task definition: tag %s uses the reserved aws: prefix", aws.ToString(tag.Key))
+		}
+	}
+
+	return &input, nil
+}
+
+// TaskDefinitionFromInput builds the task definition a register input would create, for use with DiffTaskDefinitions
+func TaskDefinitionFromInput(input *ecs.RegisterTaskDefinitionInput) *ecstypes.TaskDefinition {
+	return &ecstypes.TaskDefinition{
+		TaskDefinitionArn:       aws.String(aws.ToString(input.Family) + " (new revision)"),
+		ContainerDefinitions:    input.ContainerDefinitions,
+		Family:                  input.Family,
+		Cpu:                     input.Cpu,
+		EnableFaultInjection:    input.EnableFaultInjection,
+		EphemeralStorage:        input.EphemeralStorage,
+		ExecutionRoleArn:        input.ExecutionRoleArn,
+		InferenceAccelerators:   input.InferenceAccelerators,
+		IpcMode:                 input.IpcMode,
+		Memory:                  input.Memory,
+		NetworkMode:             input.NetworkMode,
+		PidMode:                 input.PidMode,
+		PlacementConstraints:    input.PlacementConstraints,
+		ProxyConfiguration:      input.ProxyConfiguration,
+		RequiresCompatibilities: input.RequiresCompatibilities,
+		RuntimePlatform:         input.RuntimePlatform,
+		TaskRoleArn:             input.TaskRoleArn,
+		Volumes:                 input.Volumes,
+	}
+}
+
+// toDocument converts an SDK value into plain maps and slices, renaming struct fields to camelCase
+// and leaving out unset values. Map keys such as docker labels are user data and are kept as they are.
+func toDocument(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return toDocument(v.Elem())
+	case reflect.Struct:
+		doc := make(map[string]any)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || v.Field(i).IsZero() {
+				continue
+			}
+			if value := toDocument(v.Field(i)); value != nil {
+				doc[camelCase(field.Name)] = value
+			}
+		}
+		return doc
+	case reflect.Map:
+		doc := make(map[string]any)
+		iter := v.MapRange()
+		for iter.Next() {
+			doc[fmt.Sprint(iter.Key().Interface())] = toDocument(iter.Value())
+		}
+		return doc
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return nil
+		}
+		doc := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			doc[i] = toDocument(v.Index(i))
+		}
+		return doc
+	default:
+		return v.Interface()
+	}
+}
+
+// camelCase turns an SDK field name into the name used by the ECS API, e.g. TaskRoleArn to taskRoleArn
+func camelCase(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,197 @@
+package aws
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+func testRegisterInput() *ecs.RegisterTaskDefinitionInput {
+	return &ecs.RegisterTaskDefinitionInput{
+		Family:                  aws.String("web"),
+		Cpu:                     aws.String("256"),
+		Memory:                  aws.String("512"),
+		NetworkMode:             ecstypes.NetworkModeAwsvpc,
+		RequiresCompatibilities: []ecstypes.Compatibility{ecstypes.CompatibilityFargate},
+		TaskRoleArn:             aws.String("arn:aws:iam::123456789012:role/web"),
+		ContainerDefinitions: []ecstypes.ContainerDefinition{
+			{
+				Name:      aws.String("app"),
+				Image:     aws.String("nginx:1.27"),
+				Essential: aws.Bool(true),
+				Command:   []string{"nginx", "-g", "daemon off;"},
+				Environment: []ecstypes.KeyValuePair{
+					{Name: aws.String("LOG_LEVEL"), Value: aws.String("debug")},
+				},
+				PortMappings: []ecstypes.PortMapping{
+					{ContainerPort: aws.Int32(80), Protocol: ecstypes.TransportProtocolTcp},
+				},
+				DockerLabels: map[string]string{"com.example.Team": "Platform"},
+				LogConfiguration: &ecstypes.LogConfiguration{
+					LogDriver: ecstypes.LogDriverAwslogs,
+					Options:   map[string]string{"awslogs-group": "/ecs/web", "awslogs-stream-prefix": "web"},
+				},
+			},
+		},
+		Tags: []ecstypes.Tag{{Key: aws.String("team"), Value: aws.String("platform")}},
+	}
+}
+
+func TestMarshalRegisterInputRoundTrip(t *testing.T) {
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			input := testRegisterInput()
+			document, err := MarshalRegisterInput(input, format)
+			if err != nil {
+				t.Fatalf("MarshalRegisterInput() returned error: %v", err)
+			}
+
+			got, err := UnmarshalRegisterInput(document, format)
+			if err != nil {
+				t.Fatalf("UnmarshalRegisterInput() returned error: %v\n%s", err, document)
+			}
+			if !reflect.DeepEqual(got, input) {
+				t.Errorf("round trip changed the input\ngot:  %+v\nwant: %+v", got, input)
+			}
+		})
+	}
+}
+
+func TestMarshalRegisterInputFieldNames(t *testing.T) {
+	document, err := MarshalRegisterInput(testRegisterInput(), "json")
+	if err != nil {
+		t.Fatalf("MarshalRegisterInput() returned error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(document, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	for _, key := range []string{"family", "cpu", "networkMode", "requiresCompatibilities", "taskRoleArn", "containerDefinitions", "tags"} {
+		if _, ok := doc[key]; !ok {
+			t.Errorf("missing top level key %q", key)
+		}
+	}
+	// Unset fields are left out rather than written as null or zero values
+	for _, key := range []string{"Family", "executionRoleArn", "volumes", "pidMode"} {
+		if _, ok := doc[key]; ok {
+			t.Errorf("unexpected top level key %q", key)
+		}
+	}
+
+	container := doc["containerDefinitions"].([]any)[0].(map[string]any)
+	for _, key := range []string{"name", "image", "essential", "portMappings", "dockerLabels", "logConfiguration"} {
+		if _, ok := container[key]; !ok {
+			t.Errorf("missing container key %q", key)
+		}
+	}
+
+	// Map keys are user data and must not be renamed
+	labels := container["dockerLabels"].(map[string]any)
+	if labels["com.example.Team"] != "Platform" {
+		t.Errorf("docker labels = %v, want the key com.example.Team kept as it is", labels)
+	}
+}
+
+func TestUnmarshalRegisterInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		document string
+		wantErr  string
+	}{
+		{
+			name:     "camelCase json",
+			format:   "json",
+			document: `{"family": "web", "containerDefinitions": [{"name": "app", "image": "nginx"}]}`,
+		},
+		{
+			name:     "SDK field names",
+			format:   "json",
+			document: `{"Family": "web", "ContainerDefinitions": [{"Name": "app", "Image": "nginx"}]}`,
+		},
+		{
+			name:     "yaml",
+			format:   "yaml",
+			document: "family: web\ncontainerDefinitions:\n  - name: app\n    image: nginx\n",
+		},
+		{
+			name:     "read-only field",
+			format:   "json",
+			document: `{"family": "web", "revision": 3, "containerDefinitions": [{"name": "app", "image": "nginx"}]}`,
+			wantErr:  "unknown field",
+		},
+		{
+			name:     "unknown container field",
+			format:   "yaml",
+			document: "family: web\ncontainerDefinitions:\n  - name: app\n    image: nginx\n    imageDigest: sha256:abc\n",
+			wantErr:  "unknown field",
+		},
+		{
+			name:     "missing family",
+			format:   "json",
+			document: `{"containerDefinitions": [{"name": "app", "image": "nginx"}]}`,
+			wantErr:  "family is required",
+		},
+		{
+			name:     "no containers",
+			format:   "json",
+			document: `{"family": "web"}`,
+			wantErr:  "at least one container definition",
+		},
+		{
+			name:     "container without a name",
+			format:   "json",
+			document: `{"family": "web", "containerDefinitions": [{"image": "nginx"}]}`,
+			wantErr:  "has no name",
+		},
+		{
+			name:     "container without an image",
+			format:   "json",
+			document: `{"family": "web", "containerDefinitions": [{"name": "app"}]}`,
+			wantErr:  "has no image",
+		},
+		{
+			name:     "reserved tag",
+			format:   "json",
+			document: `{"family": "web", "containerDefinitions": [{"name": "app", "image": "nginx"}], "tags": [{"key": "AWS:cloudformation:stack-name", "value": "web"}]}`,
+			wantErr:  "reserved aws: prefix",
+		},
+		{
+			name:     "invalid yaml",
+			format:   "yaml",
+			document: "family: [web",
+			wantErr:  "invalid YAML",
+		},
+		{
+			name:     "unsupported format",
+			format:   "toml",
+			document: `family = "web"`,
+			wantErr:  "unsupported format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := UnmarshalRegisterInput([]byte(tt.document), tt.format)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UnmarshalRegisterInput() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UnmarshalRegisterInput() returned error: %v", err)
+			}
+			if aws.ToString(input.Family) != "web" || aws.ToString(input.ContainerDefinitions[0].Image) != "nginx" {
+				t.Errorf("UnmarshalRegisterInput() = %+v, want family web with an nginx container", input)
+			}
+		})
+	}
+}
@@ -10,3 +10,12 @@ import (
 func LoadAWSConfig(region string) (aws.Config, error) {
 	return config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
 }
+
+// LoadAWSConfigWithProfile loads the configuration of a named shared config profile. Unlike setting
+// AWS_PROFILE, an explicit profile takes precedence over credentials in the environment.
+func LoadAWSConfigWithProfile(region, profile string) (aws.Config, error) {
+	if profile == "" {
+		return LoadAWSConfig(region)
+	}
+	return config.LoadDefaultConfig(context.Background(), config.WithRegion(region), config.WithSharedConfigProfile(profile))
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -8,12 +9,21 @@ import (
 )
 
 func CreatePrompt(items []string, label string) (int, string, error) {
+	return CreateSearchPrompt(items, nil, label)
+}
+
+// CreateSearchPrompt works like CreatePrompt but searching also matches searchKeys[i] for items[i],
+// e.g. so an item displayed by its short name can still be found by its full ARN
+func CreateSearchPrompt(items, searchKeys []string, label string) (int, string, error) {
 	prompt := promptui.Select{
 		Label: label,
 		Items: items,
 		Size:  30,
 		Searcher: func(input string, index int) bool {
 			item := items[index]
+			if index < len(searchKeys) && containsIgnoreCase(searchKeys[index], input) {
+				return true
+			}
 			return containsIgnoreCase(item, input)
 		},
 	}
@@ -25,6 +35,84 @@ func CreatePrompt(items []string, label string) (int, string, error) {
 	return index, output, nil
 }
 
+// CreateMultiSelectPrompt lets the user toggle any number of items and returns the selected indexes
+func CreateMultiSelectPrompt(items []string, label string) ([]int, error) {
+	selected := make([]bool, len(items))
+	cursor := 0
+
+	// promptui has no multi-select so re-run a single select until the user chooses Done
+	for {
+		options := []string{"Done"}
+		for i, item := range items {
+			mark := "[ ]"
+			if selected[i] {
+				mark = "[x]"
+			}
+			options = append(options, fmt.Sprintf("%s %s", mark, item))
+		}
+
+		prompt := promptui.Select{
+			Label:     label,
+			Items:     options,
+			Size:      30,
+			CursorPos: cursor,
+			Searcher: func(input string, index int) bool {
+				item := options[index]
+				return containsIgnoreCase(item, input)
+			},
+		}
+
+		index, _, err := prompt.Run()
+		if err != nil {
+			return nil, fmt.Errorf("prompt failed: %w", err)
+		}
+		if index == 0 {
+			break
+		}
+		selected[index-1] = !selected[index-1]
+		cursor = index
+	}
+
+	var indexes []int
+	for i, isSelected := range selected {
+		if isSelected {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes, nil
+}
+
+// CreateInputPrompt asks the user for free text, re-prompting until validate accepts it
+func CreateInputPrompt(label string, validate func(string) error) (string, error) {
+	prompt := promptui.Prompt{
+		Label:    label,
+		Validate: validate,
+	}
+
+	output, err := prompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("prompt failed: %w", err)
+	}
+	return output, nil
+}
+
+// Confirm asks the user a yes/no question and reports whether they answered yes
+func Confirm(label string) (bool, error) {
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+
+	_, err := prompt.Run()
+	if err != nil {
+		if errors.Is(err, promptui.ErrAbort) {
+			return false, nil
+		}
+		return false, fmt.Errorf("prompt failed: %w", err)
+	}
+	return true, nil
+}
+
 func containsIgnoreCase(str, substr string) bool {
 	return strings.Contains(strings.ToLower(str), strings.ToLower(substr))
 }